@@ -0,0 +1,3 @@
+output "aligned" {
+  value = provider::resolver::aligned(["a", "b", "c"], ["1", "2", "3"])
+}
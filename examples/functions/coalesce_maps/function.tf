@@ -0,0 +1,3 @@
+output "region" {
+  value = provider::resolver::coalesce_maps([resolver_map.overrides.result, resolver_map.defaults.result], "region")
+}
@@ -0,0 +1,3 @@
+output "assigned_and_owned" {
+  value = provider::resolver::common_keys(resolver_map.owners.result, resolver_map.assignments.result)
+}
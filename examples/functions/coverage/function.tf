@@ -0,0 +1,3 @@
+output "coverage" {
+  value = provider::resolver::coverage(["a", "b"], ["a", "b", "c", "d"], ["1", "2"])
+}
@@ -0,0 +1,3 @@
+output "has_all_endpoints" {
+  value = provider::resolver::covers(resolver_map.example.result, ["a", "c"])
+}
@@ -0,0 +1,3 @@
+output "enumeration" {
+  value = provider::resolver::enumerate(["a", "b", "c"], ["a", "c", "d"], ["1", "2", "3"])
+}
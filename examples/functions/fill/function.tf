@@ -0,0 +1,3 @@
+output "feature_flags" {
+  value = provider::resolver::fill(["search", "billing", "reports"], "enabled")
+}
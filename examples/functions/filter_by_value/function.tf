@@ -0,0 +1,3 @@
+output "enabled_features" {
+  value = provider::resolver::filter_by_value(resolver_map.example.result, "^(true|on)$")
+}
@@ -0,0 +1,3 @@
+output "has_exactly_regions" {
+  value = provider::resolver::keys_match(resolver_map.example.result, ["us-east-1", "eu-west-1"])
+}
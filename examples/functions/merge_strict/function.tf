@@ -0,0 +1,3 @@
+output "merged" {
+  value = provider::resolver::merge_strict({ a = "1", b = "2" }, { b = "2", c = "3" })
+}
@@ -0,0 +1,3 @@
+output "resolved" {
+  value = provider::resolver::require_subset_projection(["a", "b", "c"], ["c", "a"], ["1", "2", "3"])
+}
@@ -0,0 +1,3 @@
+output "resolution" {
+  value = provider::resolver::resolve_full(["a", "b", "c"], ["c", "a"], ["1", "2", "3"])
+}
@@ -0,0 +1,6 @@
+output "resolved" {
+  value = provider::resolver::resolve_many([
+    { keys = ["a", "b"], result_keys = ["a"], values = ["1", "2"] },
+    { keys = ["x", "y"], result_keys = ["y", "x"], values = ["3", "4"] },
+  ])
+}
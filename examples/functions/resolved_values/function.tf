@@ -0,0 +1,3 @@
+output "resolved" {
+  value = provider::resolver::resolved_values(["a", "b", "c"], ["c", "a"], ["y", "z", "x"])
+}
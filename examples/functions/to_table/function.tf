@@ -0,0 +1,3 @@
+output "settings_table" {
+  value = join("\n", [for row in provider::resolver::to_table(resolver_map.example.result) : join(" | ", row)])
+}
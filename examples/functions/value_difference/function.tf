@@ -0,0 +1,3 @@
+output "unassigned_owners" {
+  value = provider::resolver::value_difference(resolver_map.owners.result, resolver_map.assignments.result)
+}
@@ -0,0 +1,5 @@
+resource "resolver_list_lookup" "example" {
+  index  = -1
+  values = ["a", "b", "c"]
+  wrap   = true
+}
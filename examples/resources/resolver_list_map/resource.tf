@@ -0,0 +1,8 @@
+resource "resolver_list_map" "example" {
+  result_keys = ["a", "c"]
+  source = {
+    a = ["1", "2"]
+    b = ["3"]
+    c = []
+  }
+}
@@ -0,0 +1,2 @@
+# The ID is a JSON object whose keys become both keys and result_keys.
+terraform import resolver_map.example '{"a":"1","b":"2"}'
@@ -0,0 +1,7 @@
+resource "resolver_string_map" "example" {
+  key_max_length = 16
+  keys           = ["primary", "secondary"]
+  result_keys    = ["primary"]
+  value_format   = "url"
+  values         = ["https://primary.example.com", "https://secondary.example.com"]
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*AlignedFunction)(nil)
+
+func NewAlignedFunction() function.Function {
+	return &AlignedFunction{}
+}
+
+type AlignedFunction struct{}
+
+func (f *AlignedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Checks whether keys and values have the same length.",
+		MarkdownDescription: "Returns whether keys and values have the same length, as required by `resolver_map`, so it can be checked in a precondition before apply. Unknown elements do not affect the result.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *AlignedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "aligned"
+}
+
+func (f *AlignedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keys, values types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keys, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, aligned(keys, values))
+}
+
+// aligned returns whether the lists have the same length, which is unknown if either list is.
+func aligned(keys, values basetypes.ListValue) basetypes.BoolValue {
+	if keys.IsUnknown() || values.IsUnknown() {
+		return basetypes.NewBoolUnknown()
+	}
+
+	return basetypes.NewBoolValue(len(keys.Elements()) == len(values.Elements()))
+}
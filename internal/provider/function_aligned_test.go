@@ -0,0 +1,94 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionAligned(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "aligned" {
+					value = provider::resolver::aligned(["a", "b"], ["1", "2"])
+				}
+
+				output "misaligned" {
+					value = provider::resolver::aligned(["a", "b"], ["1"])
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("aligned", "true"),
+					resource.TestCheckOutput("misaligned", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestInternalAligned(t *testing.T) {
+	var tests = []struct {
+		keys, values   basetypes.ListValue
+		expectedResult basetypes.BoolValue
+	}{
+		// aligned, including unknown elements
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			}),
+			values: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewBoolValue(true),
+		},
+		// misaligned
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			values:         basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// unknown list
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			values:         basetypes.NewListUnknown(types.StringType),
+			expectedResult: basetypes.NewBoolUnknown(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.values, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := aligned(test.keys, test.values)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
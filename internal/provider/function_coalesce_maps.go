@@ -0,0 +1,83 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*CoalesceMapsFunction)(nil)
+
+func NewCoalesceMapsFunction() function.Function {
+	return &CoalesceMapsFunction{}
+}
+
+type CoalesceMapsFunction struct{}
+
+func (f *CoalesceMapsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the first value for a key across an ordered list of maps.",
+		MarkdownDescription: "Returns the value for key in the first of sources that has a non-null value for it, or null when none of them do. The result is unknown when an earlier map or its value for key is unknown, as it may yet take precedence.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The maps to look key up in, in order of precedence.",
+				ElementType:        types.MapType{ElemType: types.StringType},
+				Name:               "sources",
+			},
+			function.StringParameter{
+				AllowUnknownValues: true,
+				Description:        "The key to look up.",
+				Name:               "key",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CoalesceMapsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "coalesce_maps"
+}
+
+func (f *CoalesceMapsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var sources types.List
+	var key types.String
+
+	resp.Error = req.Arguments.Get(ctx, &sources, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, coalesceMaps(sources, key))
+}
+
+// coalesceMaps returns the first non-null value for key in sources, or null if there is none. Anything unknown before
+// that value is found makes the result unknown.
+func coalesceMaps(sources basetypes.ListValue, key basetypes.StringValue) basetypes.StringValue {
+	if sources.IsUnknown() || key.IsUnknown() {
+		return basetypes.NewStringUnknown()
+	}
+
+	for _, element := range sources.Elements() {
+		source := element.(basetypes.MapValue)
+
+		if source.IsUnknown() {
+			return basetypes.NewStringUnknown()
+		}
+
+		value, ok := source.Elements()[key.ValueString()].(basetypes.StringValue)
+		if !ok || value.IsNull() {
+			continue
+		}
+
+		return value
+	}
+
+	return basetypes.NewStringNull()
+}
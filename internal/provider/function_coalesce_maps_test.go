@@ -0,0 +1,127 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionCoalesceMaps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "first" {
+					value = provider::resolver::coalesce_maps([{ b = "1" }, { a = "2" }, { a = "3" }], "a")
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("first", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestInternalCoalesceMaps(t *testing.T) {
+	mapType := types.MapType{ElemType: types.StringType}
+	sources := func(maps ...attr.Value) basetypes.ListValue {
+		return basetypes.NewListValueMust(mapType, maps)
+	}
+	source := func(values map[string]attr.Value) attr.Value {
+		return basetypes.NewMapValueMust(types.StringType, values)
+	}
+
+	var tests = []struct {
+		sources        basetypes.ListValue
+		key            basetypes.StringValue
+		expectedResult basetypes.StringValue
+	}{
+		// earlier maps take precedence, skipping null values
+		{
+			sources: sources(
+				source(map[string]attr.Value{"a": basetypes.NewStringNull()}),
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("2")}),
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("3")}),
+			),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringValue("2"),
+		},
+		// absent from all
+		{
+			sources: sources(
+				source(map[string]attr.Value{"b": basetypes.NewStringValue("1")}),
+			),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringNull(),
+		},
+		{
+			sources:        sources(),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringNull(),
+		},
+		// unknown value or map before the value is found
+		{
+			sources: sources(
+				source(map[string]attr.Value{"a": basetypes.NewStringUnknown()}),
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("2")}),
+			),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		{
+			sources: sources(
+				basetypes.NewMapUnknown(types.StringType),
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("2")}),
+			),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		// unknown after the value is found
+		{
+			sources: sources(
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("1")}),
+				basetypes.NewMapUnknown(types.StringType),
+			),
+			key:            basetypes.NewStringValue("a"),
+			expectedResult: basetypes.NewStringValue("1"),
+		},
+		// unknown key
+		{
+			sources: sources(
+				source(map[string]attr.Value{"a": basetypes.NewStringValue("1")}),
+			),
+			key:            basetypes.NewStringUnknown(),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.sources, test.key, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := coalesceMaps(test.sources, test.key)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
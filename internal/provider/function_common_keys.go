@@ -0,0 +1,79 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*CommonKeysFunction)(nil)
+
+func NewCommonKeysFunction() function.Function {
+	return &CommonKeysFunction{}
+}
+
+type CommonKeysFunction struct{}
+
+func (f *CommonKeysFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Finds the keys that two maps have in common.",
+		MarkdownDescription: "Returns the sorted list of keys present in both a and b, for reconciliation. As the keys of a map are always known, only the values may be unknown, and they are not compared. The result is unknown while either map is.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The first map.",
+				ElementType:        types.StringType,
+				Name:               "a",
+			},
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The second map.",
+				ElementType:        types.StringType,
+				Name:               "b",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *CommonKeysFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "common_keys"
+}
+
+func (f *CommonKeysFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b types.Map
+
+	resp.Error = req.Arguments.Get(ctx, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	if a.IsUnknown() || b.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewListUnknown(types.StringType))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, commonKeys(a, b))
+}
+
+// commonKeys returns the keys of a that are also keys of b, sorted.
+func commonKeys(a, b basetypes.MapValue) basetypes.ListValue {
+	common := make([]attr.Value, 0)
+
+	for _, key := range sortedKeys(a) {
+		if _, ok := b.Elements()[key]; ok {
+			common = append(common, basetypes.NewStringValue(key))
+		}
+	}
+
+	return basetypes.NewListValueMust(types.StringType, common)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionCommonKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "common" {
+					value = provider::resolver::common_keys({ c = "1", a = "2", b = "3" }, { a = "x", c = "y", d = "z" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("common", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"),
+						knownvalue.StringExact("c"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalCommonKeys(t *testing.T) {
+	var tests = []struct {
+		a, b           basetypes.MapValue
+		expectedResult basetypes.ListValue
+	}{
+		// overlap, sorted, regardless of values
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("1"),
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("3"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringNull(),
+				"c": basetypes.NewStringValue("2"),
+				"d": basetypes.NewStringValue("4"),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			}),
+		},
+		// disjoint
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.a, test.b, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := commonKeys(test.a, test.b)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
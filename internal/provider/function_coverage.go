@@ -0,0 +1,105 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*CoverageFunction)(nil)
+
+func NewCoverageFunction() function.Function {
+	return &CoverageFunction{}
+}
+
+type CoverageFunction struct{}
+
+func (f *CoverageFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the share of result keys that resolve to known values.",
+		MarkdownDescription: "Returns a number between 0 and 1 for the share of result_keys that `resolver_map` would resolve to a known value, which is 1 when there are no result_keys. Returns null when this cannot be decided yet as a relevant key or value is unknown.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys, must be in same order as values.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys to resolve.",
+				ElementType:        types.StringType,
+				Name:               "result_keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values, must be in same order as keys.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *CoverageFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "coverage"
+}
+
+func (f *CoverageFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyList, resultKeyList, valueList types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keyList, &resultKeyList, &valueList)
+	if resp.Error != nil {
+		return
+	}
+
+	if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewFloat64Null())
+		return
+	}
+
+	if len(keyList.Elements()) != len(valueList.Elements()) {
+		resp.Error = function.NewArgumentFuncError(2, "Value count does not match the number of keys")
+		return
+	}
+
+	var keys, resultKeys, values []basetypes.StringValue
+
+	resp.Error = function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, coverage(keys, resultKeys, values))
+}
+
+// coverage returns the share of the distinct result keys that resolved, from the same counts as result_count, or null
+// while any of them is unknown.
+func coverage(keys, resultKeys, values []basetypes.StringValue) basetypes.Float64Value {
+	counts := resolve(keys, resultKeys, values).count()
+	if counts.IsUnknown() {
+		return basetypes.NewFloat64Null()
+	}
+
+	attributes := counts.Attributes()
+	total := attributes["total"].(basetypes.Int64Value).ValueInt64()
+
+	if attributes["unknown"].(basetypes.Int64Value).ValueInt64() > 0 {
+		return basetypes.NewFloat64Null()
+	}
+
+	if total == 0 {
+		return basetypes.NewFloat64Value(1)
+	}
+
+	return basetypes.NewFloat64Value(float64(attributes["resolved"].(basetypes.Int64Value).ValueInt64()) / float64(total))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionCoverage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "coverage" {
+					value = provider::resolver::coverage(["a", "b"], ["a", "b", "c", "d"], ["1", "2"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("coverage", knownvalue.Float64Exact(0.5)),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalCoverage(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		values     []basetypes.StringValue
+		expected   basetypes.Float64Value
+	}{
+		// full
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b"), basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			expected:   basetypes.NewFloat64Value(1),
+		},
+		// partial
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b"), basetypes.NewStringValue("c"), basetypes.NewStringValue("d")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewFloat64Value(0.25),
+		},
+		// no result keys
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewFloat64Value(1),
+		},
+		// undecidable
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringUnknown()},
+			expected:   basetypes.NewFloat64Null(),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewFloat64Null(),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringUnknown()},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewFloat64Null(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := coverage(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
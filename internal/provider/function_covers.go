@@ -0,0 +1,93 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*CoversFunction)(nil)
+
+func NewCoversFunction() function.Function {
+	return &CoversFunction{}
+}
+
+type CoversFunction struct{}
+
+func (f *CoversFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Checks whether a map has a known value for every required key.",
+		MarkdownDescription: "Returns true when every required key is in source with a known value, false when any of them is missing, and null when this cannot be decided yet as a relevant value is unknown.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map to check.",
+				ElementType:        types.StringType,
+				Name:               "source",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The keys that must be in source.",
+				ElementType:        types.StringType,
+				Name:               "required",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CoversFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "covers"
+}
+
+func (f *CoversFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var source types.Map
+	var required types.List
+
+	resp.Error = req.Arguments.Get(ctx, &source, &required)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, covers(source, required))
+}
+
+// covers returns whether every required key has a known value in source, or null if that depends on unknown values.
+// A key that is definitely missing decides the result even when other values are unknown.
+func covers(source basetypes.MapValue, required basetypes.ListValue) basetypes.BoolValue {
+	if source.IsUnknown() || required.IsUnknown() {
+		return basetypes.NewBoolNull()
+	}
+
+	undecided := false
+
+	for _, element := range required.Elements() {
+		key := element.(basetypes.StringValue)
+
+		if key.IsUnknown() {
+			undecided = true
+			continue
+		}
+
+		value, ok := source.Elements()[key.ValueString()]
+		if !ok || value.IsNull() {
+			return basetypes.NewBoolValue(false)
+		}
+
+		if value.IsUnknown() {
+			undecided = true
+		}
+	}
+
+	if undecided {
+		return basetypes.NewBoolNull()
+	}
+
+	return basetypes.NewBoolValue(true)
+}
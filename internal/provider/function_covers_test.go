@@ -0,0 +1,129 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionCovers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "covered" {
+					value = provider::resolver::covers({ a = "1", b = "2" }, ["a", "b"])
+				}
+
+				output "not_covered" {
+					value = provider::resolver::covers({ a = "1" }, ["a", "b"])
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("covered", "true"),
+					resource.TestCheckOutput("not_covered", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestInternalCovers(t *testing.T) {
+	var tests = []struct {
+		source         basetypes.MapValue
+		required       basetypes.ListValue
+		expectedResult basetypes.BoolValue
+	}{
+		// covered
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			expectedResult: basetypes.NewBoolValue(true),
+		},
+		// not covered, even though another value is unknown
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// null values are not covered
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringNull(),
+			}),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// required value unknown
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+			}),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			}),
+			expectedResult: basetypes.NewBoolNull(),
+		},
+		// required key unknown
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewBoolNull(),
+		},
+		// source unknown
+		{
+			source: basetypes.NewMapUnknown(types.StringType),
+			required: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			expectedResult: basetypes.NewBoolNull(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.source, test.required, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := covers(test.source, test.required)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
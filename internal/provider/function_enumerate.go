@@ -0,0 +1,119 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*EnumerateFunction)(nil)
+
+func NewEnumerateFunction() function.Function {
+	return &EnumerateFunction{}
+}
+
+type EnumerateFunction struct{}
+
+var enumerationType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":    types.StringType,
+		"status": types.StringType,
+		"value":  types.StringType,
+	},
+}
+
+func (f *EnumerateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Lists how each result key resolves, for debugging.",
+		MarkdownDescription: "Returns an object with the key, value and status of each distinct result key, in the order of result_keys, as `resolver_map` would resolve them. The status is `resolved`, `value_unknown`, `key_unknown` or `missing`, and the value is null unless the key was found. The result is unknown while any result key is unknown.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys, must be in same order as values.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys to enumerate.",
+				ElementType:        types.StringType,
+				Name:               "result_keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values, must be in same order as keys.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: enumerationType,
+		},
+	}
+}
+
+func (f *EnumerateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "enumerate"
+}
+
+func (f *EnumerateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyList, resultKeyList, valueList types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keyList, &resultKeyList, &valueList)
+	if resp.Error != nil {
+		return
+	}
+
+	if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewListUnknown(enumerationType))
+		return
+	}
+
+	if len(keyList.Elements()) != len(valueList.Elements()) {
+		resp.Error = function.NewArgumentFuncError(2, "Value count does not match the number of keys")
+		return
+	}
+
+	var keys, resultKeys, values []basetypes.StringValue
+
+	resp.Error = function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, enumerate(keys, resultKeys, values))
+}
+
+// enumerate lists the key, value and status of each distinct result key in order, or unknown if the result keys are.
+func enumerate(keys, resultKeys, values []basetypes.StringValue) basetypes.ListValue {
+	res := resolve(keys, resultKeys, values)
+	if res.entriesUnknown {
+		return basetypes.NewListUnknown(enumerationType)
+	}
+
+	enumeration := make([]attr.Value, len(res.entries))
+
+	for i, entry := range res.entries {
+		value := basetypes.NewStringNull()
+		if entry.found {
+			value = entry.value
+		}
+
+		enumeration[i] = basetypes.NewObjectValueMust(enumerationType.AttrTypes, map[string]attr.Value{
+			"key":    basetypes.NewStringValue(entry.key),
+			"status": basetypes.NewStringValue(res.status(entry)),
+			"value":  value,
+		})
+	}
+
+	return basetypes.NewListValueMust(enumerationType, enumeration)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionEnumerate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "enumeration" {
+					value = provider::resolver::enumerate(["a", "b"], ["b", "c"], ["1", "2"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("enumeration", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":    knownvalue.StringExact("b"),
+							"status": knownvalue.StringExact("resolved"),
+							"value":  knownvalue.StringExact("2"),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":    knownvalue.StringExact("c"),
+							"status": knownvalue.StringExact("missing"),
+							"value":  knownvalue.Null(),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalEnumerate(t *testing.T) {
+	entry := func(key, status string, value basetypes.StringValue) attr.Value {
+		return basetypes.NewObjectValueMust(enumerationType.AttrTypes, map[string]attr.Value{
+			"key":    basetypes.NewStringValue(key),
+			"status": basetypes.NewStringValue(status),
+			"value":  value,
+		})
+	}
+
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.ListValue
+	}{
+		// every status, in order of result keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("c"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewListValueMust(enumerationType, []attr.Value{
+				entry("c", "key_unknown", basetypes.NewStringNull()),
+				entry("b", "value_unknown", basetypes.NewStringUnknown()),
+				entry("a", "resolved", basetypes.NewStringValue("1")),
+			}),
+		},
+		// missing
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewListValueMust(enumerationType, []attr.Value{
+				entry("b", "missing", basetypes.NewStringNull()),
+			}),
+		},
+		// result key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewListUnknown(enumerationType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := enumerate(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*FillFunction)(nil)
+
+func NewFillFunction() function.Function {
+	return &FillFunction{}
+}
+
+type FillFunction struct{}
+
+func (f *FillFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds a map where every key has the same value.",
+		MarkdownDescription: "Returns a map from each of keys to default, for uniform maps. As the keys of a map must be known, the result is unknown while any of keys is, while an unknown default only makes the values unknown.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The keys of the map.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.StringParameter{
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+				Description:        "The value of every key.",
+				Name:               "default",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *FillFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fill"
+}
+
+func (f *FillFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keys types.List
+	var value types.String
+
+	resp.Error = req.Arguments.Get(ctx, &keys, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	for _, element := range keys.Elements() {
+		if element.IsNull() {
+			resp.Error = function.NewArgumentFuncError(0, "Keys must not be null")
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, fill(keys, value))
+}
+
+// fill returns a map from each key to value, or unknown while any key is.
+func fill(keys basetypes.ListValue, value basetypes.StringValue) basetypes.MapValue {
+	if keys.IsUnknown() {
+		return basetypes.NewMapUnknown(types.StringType)
+	}
+
+	filled := make(map[string]attr.Value, len(keys.Elements()))
+
+	for _, element := range keys.Elements() {
+		key := element.(basetypes.StringValue)
+
+		if key.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		}
+
+		filled[key.ValueString()] = value
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, filled)
+}
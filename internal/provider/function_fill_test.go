@@ -0,0 +1,104 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionFill(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "filled" {
+					value = provider::resolver::fill(["a", "b", "a"], "enabled")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filled", knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("enabled"),
+						"b": knownvalue.StringExact("enabled"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalFill(t *testing.T) {
+	var tests = []struct {
+		keys           basetypes.ListValue
+		value          basetypes.StringValue
+		expectedResult basetypes.MapValue
+	}{
+		// every key
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			}),
+			value: basetypes.NewStringValue("x"),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("x"),
+				"b": basetypes.NewStringValue("x"),
+			}),
+		},
+		// unknown default
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			value: basetypes.NewStringUnknown(),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown key
+		{
+			keys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			}),
+			value:          basetypes.NewStringValue("x"),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+		// unknown keys
+		{
+			keys:           basetypes.NewListUnknown(types.StringType),
+			value:          basetypes.NewStringValue("x"),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.value)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := fill(test.keys, test.value)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*FilterByValueFunction)(nil)
+
+func NewFilterByValueFunction() function.Function {
+	return &FilterByValueFunction{}
+}
+
+type FilterByValueFunction struct{}
+
+func (f *FilterByValueFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Filters a map down to the entries whose value matches a regular expression.",
+		MarkdownDescription: "Returns the entries of source whose value matches regex, leaving out null values. As whether an unknown value matches cannot be known yet, the result is unknown while any value is unknown.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map to filter.",
+				ElementType:        types.StringType,
+				Name:               "source",
+			},
+			function.StringParameter{
+				AllowUnknownValues: true,
+				Description:        "The regular expression that values must match, which may match any part of the value unless anchored.",
+				Name:               "regex",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *FilterByValueFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "filter_by_value"
+}
+
+func (f *FilterByValueFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var source types.Map
+	var regex types.String
+
+	resp.Error = req.Arguments.Get(ctx, &source, &regex)
+	if resp.Error != nil {
+		return
+	}
+
+	if source.IsUnknown() || regex.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewMapUnknown(types.StringType))
+		return
+	}
+
+	compiled, err := compileRegex(regex.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "Invalid regular expression: "+err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, filterByValue(source, compiled))
+}
+
+// filterByValue returns the entries of source whose known value matches regex, or unknown if any value is unknown.
+func filterByValue(source basetypes.MapValue, regex *regexp.Regexp) basetypes.MapValue {
+	filtered := make(map[string]attr.Value)
+
+	for key, element := range source.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		}
+
+		if !value.IsNull() && regex.MatchString(value.ValueString()) {
+			filtered[key] = value
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, filtered)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionFilterByValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "filtered" {
+					value = provider::resolver::filter_by_value({ a = "on", b = "off", c = "on" }, "^on$")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("filtered", knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("on"),
+						"c": knownvalue.StringExact("on"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccFunctionFilterByValueInvalidRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "filtered" {
+					value = provider::resolver::filter_by_value({ a = "on" }, "(")
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Invalid regular expression)`),
+			},
+		},
+	})
+}
+
+func TestInternalFilterByValue(t *testing.T) {
+	var tests = []struct {
+		source         basetypes.MapValue
+		regex          string
+		expectedResult basetypes.MapValue
+	}{
+		// matching values, leaving out null values
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+				"b": basetypes.NewStringValue("off"),
+				"c": basetypes.NewStringNull(),
+			}),
+			regex: "^on$",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+			}),
+		},
+		// unanchored match
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+				"b": basetypes.NewStringValue("off"),
+			}),
+			regex: "o",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+				"b": basetypes.NewStringValue("off"),
+			}),
+		},
+		// no matches
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+			}),
+			regex:          "^off$",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		// unknown value
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("on"),
+				"b": basetypes.NewStringUnknown(),
+			}),
+			regex:          "^on$",
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.source, test.regex, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := filterByValue(test.source, regexp.MustCompile(test.regex))
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*KeysMatchFunction)(nil)
+
+func NewKeysMatchFunction() function.Function {
+	return &KeysMatchFunction{}
+}
+
+type KeysMatchFunction struct{}
+
+func (f *KeysMatchFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Checks whether the keys of a map are exactly a set of keys.",
+		MarkdownDescription: "Returns true when the keys of source are exactly expected_keys, ignoring order and duplicates, false when they are not, and null when this cannot be decided yet as some of expected_keys are unknown. Values of source are not compared, so they may be unknown.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map to check.",
+				ElementType:        types.StringType,
+				Name:               "source",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The keys that source must have, and no others.",
+				ElementType:        types.StringType,
+				Name:               "expected_keys",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *KeysMatchFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "keys_match"
+}
+
+func (f *KeysMatchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var source types.Map
+	var expectedKeys types.List
+
+	resp.Error = req.Arguments.Get(ctx, &source, &expectedKeys)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, keysMatch(source, expectedKeys))
+}
+
+// keysMatch returns whether the keys of source are exactly expectedKeys, or null if that depends on unknown values.
+// A known expected key that is missing, or more extra keys than there are unknown expected keys, decides the result
+// even when some expected keys are unknown.
+func keysMatch(source basetypes.MapValue, expectedKeys basetypes.ListValue) basetypes.BoolValue {
+	if source.IsUnknown() || expectedKeys.IsUnknown() {
+		return basetypes.NewBoolNull()
+	}
+
+	expected := make(map[string]bool)
+	unknown := 0
+
+	for _, element := range expectedKeys.Elements() {
+		key := element.(basetypes.StringValue)
+
+		if key.IsUnknown() {
+			unknown += 1
+			continue
+		}
+
+		if _, ok := source.Elements()[key.ValueString()]; !ok {
+			return basetypes.NewBoolValue(false)
+		}
+
+		expected[key.ValueString()] = true
+	}
+
+	extra := len(source.Elements()) - len(expected)
+
+	switch {
+	case extra > unknown:
+		return basetypes.NewBoolValue(false)
+	case unknown > 0:
+		return basetypes.NewBoolNull()
+	default:
+		return basetypes.NewBoolValue(extra == 0)
+	}
+}
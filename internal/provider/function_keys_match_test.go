@@ -0,0 +1,116 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionKeysMatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "match" {
+					value = provider::resolver::keys_match({ a = "1", b = "2" }, ["b", "a"])
+				}
+
+				output "mismatch" {
+					value = provider::resolver::keys_match({ a = "1", b = "2" }, ["a"])
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("match", "true"),
+					resource.TestCheckOutput("mismatch", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestInternalKeysMatch(t *testing.T) {
+	source := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		expectedKeys   basetypes.ListValue
+		expectedResult basetypes.BoolValue
+	}{
+		// exact match in any order, with duplicates and unknown values
+		{
+			expectedKeys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			}),
+			expectedResult: basetypes.NewBoolValue(true),
+		},
+		// extra key in source
+		{
+			expectedKeys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// expected key missing from source
+		{
+			expectedKeys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// unknown expected key may be the extra key
+		{
+			expectedKeys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewBoolNull(),
+		},
+		// unknown expected key cannot cover both extra keys
+		{
+			expectedKeys: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewBoolValue(false),
+		},
+		// unknown expected keys
+		{
+			expectedKeys:   basetypes.NewListUnknown(types.StringType),
+			expectedResult: basetypes.NewBoolNull(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.expectedKeys, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := keysMatch(source, test.expectedKeys)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
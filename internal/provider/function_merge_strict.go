@@ -0,0 +1,114 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*MergeStrictFunction)(nil)
+
+func NewMergeStrictFunction() function.Function {
+	return &MergeStrictFunction{}
+}
+
+type MergeStrictFunction struct{}
+
+func (f *MergeStrictFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Merges two maps, failing when they disagree on a key.",
+		MarkdownDescription: "Returns the entries of both maps, like `merge(a, b)`, except that a key in both maps with different values is an error naming the key instead of b silently taking precedence. The result is unknown while an unknown value could still turn out to conflict.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The first map to merge.",
+				ElementType:        types.StringType,
+				Name:               "a",
+			},
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The second map to merge.",
+				ElementType:        types.StringType,
+				Name:               "b",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *MergeStrictFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_strict"
+}
+
+func (f *MergeStrictFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b types.Map
+
+	resp.Error = req.Arguments.Get(ctx, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	if a.IsUnknown() || b.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewMapUnknown(types.StringType))
+		return
+	}
+
+	merged, funcErr := mergeStrict(a, b)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, merged)
+}
+
+// mergeStrict merges the entries of a and b, returning an error naming the keys in both whose known values differ, as
+// found by resultMismatches. A key in both with an unknown value may still conflict, which makes the result unknown.
+func mergeStrict(a, b basetypes.MapValue) (basetypes.MapValue, *function.FuncError) {
+	var conflicts []string
+
+	for _, key := range resultMismatches(a, b) {
+		_, inA := a.Elements()[key]
+		_, inB := b.Elements()[key]
+
+		if inA && inB {
+			conflicts = append(conflicts, fmt.Sprintf("%q", key))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return basetypes.NewMapUnknown(types.StringType), function.NewFuncError(fmt.Sprintf("Maps have different values for %s", strings.Join(conflicts, ", ")))
+	}
+
+	merged := make(map[string]attr.Value, len(a.Elements())+len(b.Elements()))
+	undecided := false
+
+	for key, value := range a.Elements() {
+		merged[key] = value
+	}
+
+	for key, value := range b.Elements() {
+		if aValue, ok := merged[key]; ok && (aValue.IsUnknown() || value.IsUnknown()) {
+			undecided = true
+		}
+
+		merged[key] = value
+	}
+
+	if undecided {
+		return basetypes.NewMapUnknown(types.StringType), nil
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, merged), nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionMergeStrict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "merged" {
+					value = provider::resolver::merge_strict({ a = "1", b = "2" }, { b = "2", c = "3" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("merged", knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("2"),
+						"c": knownvalue.StringExact("3"),
+					})),
+				},
+			},
+			{
+				Config: `
+				output "merged" {
+					value = provider::resolver::merge_strict({ a = "1", b = "2" }, { b = "3" })
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Maps have different values for "b"`),
+			},
+		},
+	})
+}
+
+func TestInternalMergeStrict(t *testing.T) {
+	var tests = []struct {
+		a, b           basetypes.MapValue
+		expectedResult basetypes.MapValue
+		expectedError  bool
+	}{
+		// clean merge
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// conflict
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringNull(),
+			}),
+			expectedError: true,
+		},
+		// an unknown value in both may conflict
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.a, test.b)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult, funcErr := mergeStrict(test.a, test.b)
+
+			if (funcErr != nil) != test.expectedError {
+				t.Fatalf("Got error %+v, wanted error %t", funcErr, test.expectedError)
+			}
+
+			if !test.expectedError && !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*RequireSubsetProjectionFunction)(nil)
+
+func NewRequireSubsetProjectionFunction() function.Function {
+	return &RequireSubsetProjectionFunction{}
+}
+
+type RequireSubsetProjectionFunction struct{}
+
+func (f *RequireSubsetProjectionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Resolves a map, failing when a result key is not in keys.",
+		MarkdownDescription: "Returns the map `resolver_map` would resolve from the keys and values, projected onto result_keys. Unlike `resolver_map`, a result key that is definitely not in keys is an error naming that key rather than a null result. The result is unknown while it cannot be decided whether every result key is in keys.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys, must be in same order as values.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys to resolve, must be a subset of keys.",
+				ElementType:        types.StringType,
+				Name:               "result_keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values, must be in same order as keys.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *RequireSubsetProjectionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "require_subset_projection"
+}
+
+func (f *RequireSubsetProjectionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyList, resultKeyList, valueList types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keyList, &resultKeyList, &valueList)
+	if resp.Error != nil {
+		return
+	}
+
+	if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewMapUnknown(types.StringType))
+		return
+	}
+
+	if len(keyList.Elements()) != len(valueList.Elements()) {
+		resp.Error = function.NewArgumentFuncError(2, "Value count does not match the number of keys")
+		return
+	}
+
+	var keys, resultKeys, values []basetypes.StringValue
+
+	resp.Error = function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	result, funcErr := requireSubsetProjection(keys, resultKeys, values)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// requireSubsetProjection resolves the result keys, returning an error for the first result key that is definitely
+// missing from the keys where resolve would give a null result.
+func requireSubsetProjection(keys, resultKeys, values []basetypes.StringValue) (basetypes.MapValue, *function.FuncError) {
+	res := resolve(keys, resultKeys, values)
+	result := res.result()
+
+	if result.IsNull() {
+		for _, entry := range res.entries {
+			if !entry.found {
+				return result, function.NewArgumentFuncError(1, fmt.Sprintf("Result key %q is not in keys", entry.key))
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionRequireSubsetProjection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "resolved" {
+					value = provider::resolver::require_subset_projection(["a", "b", "c"], ["c", "a"], ["1", "2", "3"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("resolved", knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"c": knownvalue.StringExact("3"),
+					})),
+				},
+			},
+			{
+				Config: `
+				output "resolved" {
+					value = provider::resolver::require_subset_projection(["a", "b"], ["a", "d"], ["1", "2"])
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Result key "d" is not in keys`),
+			},
+		},
+	})
+}
+
+func TestInternalRequireSubsetProjection(t *testing.T) {
+	var tests = []struct {
+		keys           []basetypes.StringValue
+		resultKeys     []basetypes.StringValue
+		values         []basetypes.StringValue
+		expectedResult basetypes.MapValue
+		expectedError  bool
+	}{
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringUnknown()},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringUnknown(),
+			}),
+		},
+		{
+			keys:          []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys:    []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:        []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expectedError: true,
+		},
+		// the unknown key may be the missing result key
+		{
+			keys:           []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys:     []basetypes.StringValue{basetypes.NewStringValue("b")},
+			values:         []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult, funcErr := requireSubsetProjection(test.keys, test.resultKeys, test.values)
+
+			if (funcErr != nil) != test.expectedError {
+				t.Fatalf("Got error %+v, wanted error %t", funcErr, test.expectedError)
+			}
+
+			if !test.expectedError && !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*ResolveFullFunction)(nil)
+
+func NewResolveFullFunction() function.Function {
+	return &ResolveFullFunction{}
+}
+
+type ResolveFullFunction struct{}
+
+var fullResolutionType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"complete": types.BoolType,
+		"pairs":    types.ListType{ElemType: resultPairType},
+		"result":   types.MapType{ElemType: types.StringType},
+	},
+}
+
+func (f *ResolveFullFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Resolves a map and returns it as both a map and a list of pairs.",
+		MarkdownDescription: "Returns an object with the `result` map as `resolver_map` would resolve it, the same entries as a list of `pairs` with a key and value in the order of result_keys, and whether the result is `complete`, that is every result key was found. All three are unknown while it cannot be decided whether every result key is found, and when some result key is missing the result and pairs are null and complete is false.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys, must be in same order as values.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys to resolve.",
+				ElementType:        types.StringType,
+				Name:               "result_keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values, must be in same order as keys.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: fullResolutionType.AttrTypes,
+		},
+	}
+}
+
+func (f *ResolveFullFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resolve_full"
+}
+
+func (f *ResolveFullFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyList, resultKeyList, valueList types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keyList, &resultKeyList, &valueList)
+	if resp.Error != nil {
+		return
+	}
+
+	if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewObjectUnknown(fullResolutionType.AttrTypes))
+		return
+	}
+
+	if len(keyList.Elements()) != len(valueList.Elements()) {
+		resp.Error = function.NewArgumentFuncError(2, "Value count does not match the number of keys")
+		return
+	}
+
+	var keys, resultKeys, values []basetypes.StringValue
+
+	resp.Error = function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, resolveFull(keys, resultKeys, values))
+}
+
+// resolveFull resolves the result keys once and returns the result as a map and as pairs in the order of the result
+// keys, along with whether every result key was found.
+func resolveFull(keys, resultKeys, values []basetypes.StringValue) basetypes.ObjectValue {
+	res := resolve(keys, resultKeys, values)
+	result := res.result()
+
+	pairs := basetypes.NewListUnknown(resultPairType)
+	complete := basetypes.NewBoolUnknown()
+
+	switch {
+	case result.IsNull():
+		pairs = basetypes.NewListNull(resultPairType)
+		complete = basetypes.NewBoolValue(false)
+	case !result.IsUnknown():
+		order := make([]string, len(res.entries))
+		for i, entry := range res.entries {
+			order[i] = entry.key
+		}
+
+		pairs = resultPairs(order, result)
+		complete = basetypes.NewBoolValue(true)
+	}
+
+	return basetypes.NewObjectValueMust(fullResolutionType.AttrTypes, map[string]attr.Value{
+		"complete": complete,
+		"pairs":    pairs,
+		"result":   result,
+	})
+}
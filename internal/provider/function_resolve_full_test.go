@@ -0,0 +1,150 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionResolveFull(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "resolution" {
+					value = provider::resolver::resolve_full(["a", "b", "c"], ["c", "a"], ["1", "2", "3"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("resolution", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"complete": knownvalue.Bool(true),
+						"pairs": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key":   knownvalue.StringExact("c"),
+								"value": knownvalue.StringExact("3"),
+							}),
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"key":   knownvalue.StringExact("a"),
+								"value": knownvalue.StringExact("1"),
+							}),
+						}),
+						"result": knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.StringExact("1"),
+							"c": knownvalue.StringExact("3"),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalResolveFull(t *testing.T) {
+	pair := func(key string, value basetypes.StringValue) attr.Value {
+		return basetypes.NewObjectValueMust(resultPairType.AttrTypes, map[string]attr.Value{
+			"key":   basetypes.NewStringValue(key),
+			"value": value,
+		})
+	}
+
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.ObjectValue
+	}{
+		// complete, pairs in order of distinct result keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewObjectValueMust(fullResolutionType.AttrTypes, map[string]attr.Value{
+				"complete": basetypes.NewBoolValue(true),
+				"pairs": basetypes.NewListValueMust(resultPairType, []attr.Value{
+					pair("b", basetypes.NewStringUnknown()),
+					pair("a", basetypes.NewStringValue("1")),
+				}),
+				"result": basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+					"a": basetypes.NewStringValue("1"),
+					"b": basetypes.NewStringUnknown(),
+				}),
+			}),
+		},
+		// missing
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewObjectValueMust(fullResolutionType.AttrTypes, map[string]attr.Value{
+				"complete": basetypes.NewBoolValue(false),
+				"pairs":    basetypes.NewListNull(resultPairType),
+				"result":   basetypes.NewMapNull(types.StringType),
+			}),
+		},
+		// missing key could be the unknown key
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: basetypes.NewObjectValueMust(fullResolutionType.AttrTypes, map[string]attr.Value{
+				"complete": basetypes.NewBoolUnknown(),
+				"pairs":    basetypes.NewListUnknown(resultPairType),
+				"result":   basetypes.NewMapUnknown(types.StringType),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolveFull(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*ResolveManyFunction)(nil)
+
+func NewResolveManyFunction() function.Function {
+	return &ResolveManyFunction{}
+}
+
+type ResolveManyFunction struct{}
+
+var resolveSpecType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"keys":        types.ListType{ElemType: types.StringType},
+		"result_keys": types.ListType{ElemType: types.StringType},
+		"values":      types.ListType{ElemType: types.StringType},
+	},
+}
+
+func (f *ResolveManyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Resolves several independent maps at once.",
+		MarkdownDescription: "Returns the map each spec resolves to as `resolver_map` would resolve it, in the order of specs. Each spec is an object with keys, result_keys and values, and resolves independently of the others, so one that is unknown or has missing result keys does not affect the rest.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of objects with keys, result_keys and values to resolve.",
+				ElementType:        resolveSpecType,
+				Name:               "specs",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.MapType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (f *ResolveManyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resolve_many"
+}
+
+func (f *ResolveManyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var specs types.List
+
+	resp.Error = req.Arguments.Get(ctx, &specs)
+	if resp.Error != nil {
+		return
+	}
+
+	var result basetypes.ListValue
+
+	result, resp.Error = resolveMany(ctx, specs)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// resolveMany resolves each spec independently, in order. A spec that is unknown, or whose lists are, resolves to an
+// unknown map.
+func resolveMany(ctx context.Context, specs basetypes.ListValue) (basetypes.ListValue, *function.FuncError) {
+	mapType := types.MapType{ElemType: types.StringType}
+
+	if specs.IsUnknown() {
+		return basetypes.NewListUnknown(mapType), nil
+	}
+
+	results := make([]attr.Value, len(specs.Elements()))
+
+	for i, element := range specs.Elements() {
+		spec, ok := element.(basetypes.ObjectValue)
+		if !ok || spec.IsNull() {
+			return basetypes.ListValue{}, function.NewArgumentFuncError(0, fmt.Sprintf("Spec %d is null", i))
+		}
+
+		if spec.IsUnknown() {
+			results[i] = basetypes.NewMapUnknown(types.StringType)
+			continue
+		}
+
+		keyList := spec.Attributes()["keys"].(basetypes.ListValue)
+		resultKeyList := spec.Attributes()["result_keys"].(basetypes.ListValue)
+		valueList := spec.Attributes()["values"].(basetypes.ListValue)
+
+		if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+			results[i] = basetypes.NewMapUnknown(types.StringType)
+			continue
+		}
+
+		if len(keyList.Elements()) != len(valueList.Elements()) {
+			return basetypes.ListValue{}, function.NewArgumentFuncError(0, fmt.Sprintf("Value count of spec %d does not match the number of keys", i))
+		}
+
+		var keys, resultKeys, values []basetypes.StringValue
+
+		funcErr := function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+		funcErr = function.ConcatFuncErrors(funcErr, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+		funcErr = function.ConcatFuncErrors(funcErr, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+		if funcErr != nil {
+			return basetypes.ListValue{}, funcErr
+		}
+
+		results[i] = resolveMap(keys, resultKeys, values)
+	}
+
+	return basetypes.NewListValueMust(mapType, results), nil
+}
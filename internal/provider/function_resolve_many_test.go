@@ -0,0 +1,135 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionResolveMany(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "resolved" {
+					value = provider::resolver::resolve_many([
+						{ keys = ["a", "b"], result_keys = ["a"], values = ["1", "2"] },
+						{ keys = ["x"], result_keys = ["y"], values = ["3"] },
+					])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("resolved", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.StringExact("1"),
+						}),
+						knownvalue.Null(),
+					})),
+				},
+			},
+			{
+				Config: `
+				output "resolved" {
+					value = provider::resolver::resolve_many([
+						{ keys = ["a"], result_keys = ["a"], values = ["1"] },
+						{ keys = ["x", "y"], result_keys = ["x"], values = ["3"] },
+					])
+				}
+				`,
+				ExpectError: regexp.MustCompile("Value count of spec 1 does not match the number of keys"),
+			},
+		},
+	})
+}
+
+func TestInternalResolveMany(t *testing.T) {
+	stringList := func(values ...string) basetypes.ListValue {
+		elements := make([]attr.Value, len(values))
+		for i, value := range values {
+			elements[i] = basetypes.NewStringValue(value)
+		}
+		return basetypes.NewListValueMust(types.StringType, elements)
+	}
+	spec := func(keys, resultKeys, values basetypes.ListValue) attr.Value {
+		return basetypes.NewObjectValueMust(resolveSpecType.AttrTypes, map[string]attr.Value{
+			"keys":        keys,
+			"result_keys": resultKeys,
+			"values":      values,
+		})
+	}
+	mapType := types.MapType{ElemType: types.StringType}
+
+	var tests = []struct {
+		specs          basetypes.ListValue
+		expectedResult basetypes.ListValue
+		expectedError  bool
+	}{
+		// resolved, missing and unknown specs resolve independently
+		{
+			specs: basetypes.NewListValueMust(resolveSpecType, []attr.Value{
+				spec(stringList("a", "b"), stringList("b"), stringList("1", "2")),
+				spec(stringList("a"), stringList("c"), stringList("1")),
+				spec(basetypes.NewListUnknown(types.StringType), stringList("a"), stringList("1")),
+				basetypes.NewObjectUnknown(resolveSpecType.AttrTypes),
+			}),
+			expectedResult: basetypes.NewListValueMust(mapType, []attr.Value{
+				basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+					"b": basetypes.NewStringValue("2"),
+				}),
+				basetypes.NewMapNull(types.StringType),
+				basetypes.NewMapUnknown(types.StringType),
+				basetypes.NewMapUnknown(types.StringType),
+			}),
+		},
+		// count mismatch
+		{
+			specs: basetypes.NewListValueMust(resolveSpecType, []attr.Value{
+				spec(stringList("a"), stringList("a"), stringList("1")),
+				spec(stringList("a", "b"), stringList("a"), stringList("1")),
+			}),
+			expectedError: true,
+		},
+		// unknown specs
+		{
+			specs:          basetypes.NewListUnknown(resolveSpecType),
+			expectedResult: basetypes.NewListUnknown(mapType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.specs)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult, funcErr := resolveMany(context.Background(), test.specs)
+
+			if (funcErr != nil) != test.expectedError {
+				t.Fatalf("Got error %+v, wanted error %t", funcErr, test.expectedError)
+			}
+
+			if !test.expectedError && !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
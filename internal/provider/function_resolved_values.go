@@ -0,0 +1,118 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*ResolvedValuesFunction)(nil)
+
+func NewResolvedValuesFunction() function.Function {
+	return &ResolvedValuesFunction{}
+}
+
+type ResolvedValuesFunction struct{}
+
+func (f *ResolvedValuesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the resolved values as a sorted list.",
+		MarkdownDescription: "Returns the values that result_keys resolve to, sorted, for when only the values matter. Result keys that are not in keys and null values are left out. As the order depends on every value, the list is unknown while any resolved value is unknown, or while it cannot be decided which result keys are in keys.",
+
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys, must be in same order as values.",
+				ElementType:        types.StringType,
+				Name:               "keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of keys to resolve.",
+				ElementType:        types.StringType,
+				Name:               "result_keys",
+			},
+			function.ListParameter{
+				AllowUnknownValues: true,
+				Description:        "The list of values, must be in same order as keys.",
+				ElementType:        types.StringType,
+				Name:               "values",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ResolvedValuesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resolved_values"
+}
+
+func (f *ResolvedValuesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var keyList, resultKeyList, valueList types.List
+
+	resp.Error = req.Arguments.Get(ctx, &keyList, &resultKeyList, &valueList)
+	if resp.Error != nil {
+		return
+	}
+
+	if keyList.IsUnknown() || resultKeyList.IsUnknown() || valueList.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewListUnknown(types.StringType))
+		return
+	}
+
+	if len(keyList.Elements()) != len(valueList.Elements()) {
+		resp.Error = function.NewArgumentFuncError(2, "Value count does not match the number of keys")
+		return
+	}
+
+	var keys, resultKeys, values []basetypes.StringValue
+
+	resp.Error = function.FuncErrorFromDiags(ctx, keyList.ElementsAs(ctx, &keys, false))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, resultKeyList.ElementsAs(ctx, &resultKeys, false)))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, valueList.ElementsAs(ctx, &values, false)))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, resolvedValues(keys, resultKeys, values))
+}
+
+// resolvedValues sorts the known values of the partial result, which is unknown while any of them is unknown.
+func resolvedValues(keys, resultKeys, values []basetypes.StringValue) basetypes.ListValue {
+	result := resolve(keys, resultKeys, values).partialResult()
+	if result.IsUnknown() {
+		return basetypes.NewListUnknown(types.StringType)
+	}
+
+	var sorted []string
+
+	for _, element := range result.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			return basetypes.NewListUnknown(types.StringType)
+		}
+
+		if !value.IsNull() {
+			sorted = append(sorted, value.ValueString())
+		}
+	}
+
+	sort.Strings(sorted)
+
+	resolved := make([]attr.Value, len(sorted))
+	for i, value := range sorted {
+		resolved[i] = basetypes.NewStringValue(value)
+	}
+
+	return basetypes.NewListValueMust(types.StringType, resolved)
+}
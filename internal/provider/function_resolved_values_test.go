@@ -0,0 +1,102 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionResolvedValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "resolved" {
+					value = provider::resolver::resolved_values(["a", "b", "c"], ["c", "a", "d"], ["y", "z", "x"])
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("resolved", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("x"),
+						knownvalue.StringExact("y"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalResolvedValues(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		values     []basetypes.StringValue
+		expected   basetypes.ListValue
+	}{
+		// sorted, leaving out missing result keys and null values
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b"), basetypes.NewStringValue("c")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b"), basetypes.NewStringValue("c"), basetypes.NewStringValue("d")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("2"), basetypes.NewStringNull(), basetypes.NewStringValue("10")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("10"), basetypes.NewStringValue("2")}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+		},
+		// an unknown value could sort anywhere
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringUnknown()},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+		// the unknown key may be the missing result key
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+		// unknown values that are not resolved do not matter
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringUnknown()},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("1")}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := resolvedValues(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*ToTableFunction)(nil)
+
+func NewToTableFunction() function.Function {
+	return &ToTableFunction{}
+}
+
+type ToTableFunction struct{}
+
+var tableRowType = types.ListType{ElemType: types.StringType}
+
+func (f *ToTableFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts a map into a table of key and value rows.",
+		MarkdownDescription: "Returns a row of [key, value] for each entry of source, sorted by key. As the keys of a map are always known, an unknown value only makes the value in its row unknown, and a null value stays null.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map to convert.",
+				ElementType:        types.StringType,
+				Name:               "source",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: tableRowType,
+		},
+	}
+}
+
+func (f *ToTableFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_table"
+}
+
+func (f *ToTableFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var source types.Map
+
+	resp.Error = req.Arguments.Get(ctx, &source)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, toTable(source))
+}
+
+// toTable returns a [key, value] row for each entry of source sorted by key, or unknown if source is.
+func toTable(source basetypes.MapValue) basetypes.ListValue {
+	if source.IsUnknown() {
+		return basetypes.NewListUnknown(tableRowType)
+	}
+
+	keys := sortedKeys(source)
+	rows := make([]attr.Value, len(keys))
+
+	for i, key := range keys {
+		rows[i] = basetypes.NewListValueMust(types.StringType, []attr.Value{
+			basetypes.NewStringValue(key),
+			source.Elements()[key],
+		})
+	}
+
+	return basetypes.NewListValueMust(tableRowType, rows)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionToTable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "table" {
+					value = provider::resolver::to_table({ b = "2", a = "1" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("table", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("a"), knownvalue.StringExact("1")}),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("b"), knownvalue.StringExact("2")}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalToTable(t *testing.T) {
+	row := func(key string, value basetypes.StringValue) attr.Value {
+		return basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue(key), value})
+	}
+
+	var tests = []struct {
+		source         basetypes.MapValue
+		expectedResult basetypes.ListValue
+	}{
+		// sorted by key, keeping null and unknown values in their rows
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("3"),
+				"a": basetypes.NewStringNull(),
+				"b": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewListValueMust(tableRowType, []attr.Value{
+				row("a", basetypes.NewStringNull()),
+				row("b", basetypes.NewStringUnknown()),
+				row("c", basetypes.NewStringValue("3")),
+			}),
+		},
+		// empty
+		{
+			source:         basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			expectedResult: basetypes.NewListValueMust(tableRowType, []attr.Value{}),
+		},
+		// unknown
+		{
+			source:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewListUnknown(tableRowType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.source, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := toTable(test.source)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
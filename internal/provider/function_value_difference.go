@@ -0,0 +1,103 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = (*ValueDifferenceFunction)(nil)
+
+func NewValueDifferenceFunction() function.Function {
+	return &ValueDifferenceFunction{}
+}
+
+type ValueDifferenceFunction struct{}
+
+func (f *ValueDifferenceFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Finds the values of a map that are not values of another map.",
+		MarkdownDescription: "Returns the set of values of a that are not among the values of b, for reconciliation reporting. Null values are ignored. Unknown values in either map are also ignored rather than making the result unknown, so at plan the result only reflects the values that are already known.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map whose values are reported.",
+				ElementType:        types.StringType,
+				Name:               "a",
+			},
+			function.MapParameter{
+				AllowUnknownValues: true,
+				Description:        "The map whose values are left out.",
+				ElementType:        types.StringType,
+				Name:               "b",
+			},
+		},
+		Return: function.SetReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ValueDifferenceFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "value_difference"
+}
+
+func (f *ValueDifferenceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b types.Map
+
+	resp.Error = req.Arguments.Get(ctx, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	if a.IsUnknown() || b.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, basetypes.NewSetUnknown(types.StringType))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, valueDifference(a, b))
+}
+
+// valueDifference returns the known values of a that are not known values of b.
+func valueDifference(a, b basetypes.MapValue) basetypes.SetValue {
+	excluded := valueSet(b)
+	var difference []string
+
+	for value := range valueSet(a) {
+		if !excluded[value] {
+			difference = append(difference, value)
+		}
+	}
+
+	sort.Strings(difference)
+
+	elements := make([]attr.Value, len(difference))
+	for i, value := range difference {
+		elements[i] = basetypes.NewStringValue(value)
+	}
+
+	return basetypes.NewSetValueMust(types.StringType, elements)
+}
+
+// valueSet returns the distinct values of source, leaving out those that are null or unknown.
+func valueSet(source basetypes.MapValue) map[string]bool {
+	values := make(map[string]bool)
+
+	for _, element := range source.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if !value.IsNull() && !value.IsUnknown() {
+			values[value.ValueString()] = true
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctionValueDifference(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "difference" {
+					value = provider::resolver::value_difference({ a = "1", b = "2", c = "3" }, { x = "2" })
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("difference", knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("1"),
+						knownvalue.StringExact("3"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestInternalValueDifference(t *testing.T) {
+	var tests = []struct {
+		a, b           basetypes.MapValue
+		expectedResult basetypes.SetValue
+	}{
+		// values of a not in b, regardless of keys
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringValue("1"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewSetValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("1"),
+			}),
+		},
+		// null and unknown values are excluded
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringValue("2"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewSetValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			}),
+		},
+		// no difference
+		{
+			a: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			b: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: basetypes.NewSetValueMust(types.StringType, []attr.Value{}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.a, test.b, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := valueDifference(test.a, test.b)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
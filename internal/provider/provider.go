@@ -7,13 +7,18 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure Resolver satisfies various provider interfaces.
 var _ provider.Provider = &Resolver{}
+var _ provider.ProviderWithFunctions = &Resolver{}
 
 // Resolver defines the provider implementation.
 type Resolver struct {
@@ -31,11 +36,84 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// resolverModel describes the provider configuration.
+type resolverModel struct {
+	DefaultValues types.Map  `tfsdk:"default_values"`
+	ValidateOnly  types.Bool `tfsdk:"validate_only"`
+}
+
+// resolverData is the provider configuration passed to resources.
+type resolverData struct {
+	defaultValues basetypes.MapValue
+	validateOnly  bool
+	version       string
+}
+
+// defaults returns the values for keys whose value is null, which are null before the provider is configured.
+func (d *resolverData) defaults() basetypes.MapValue {
+	if d == nil {
+		return basetypes.NewMapNull(types.StringType)
+	}
+
+	return d.defaultValues
+}
+
+// isValidateOnly reports whether results should be withheld, which is not the case before the provider is configured.
+func (d *resolverData) isValidateOnly() bool {
+	return d != nil && d.validateOnly
+}
+
+// printResult logs result at debug level for local debugging, but only for development and test builds of the provider
+// so that release builds never print results.
+func (d *resolverData) printResult(ctx context.Context, result basetypes.MapValue) {
+	if d == nil || (d.version != "dev" && d.version != "test") {
+		return
+	}
+
+	tflog.Debug(ctx, "Resolved result", map[string]interface{}{
+		"result": result.String(),
+	})
+}
+
 func (p *Resolver) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config resolverModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.ResourceData = &resolverData{
+		defaultValues: config.DefaultValues,
+		validateOnly:  config.ValidateOnly.ValueBool(),
+		version:       p.version,
+	}
 }
 
 func (p *Resolver) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{}
+}
+
+func (p *Resolver) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewAlignedFunction,
+		NewCoalesceMapsFunction,
+		NewCommonKeysFunction,
+		NewCoverageFunction,
+		NewCoversFunction,
+		NewEnumerateFunction,
+		NewFillFunction,
+		NewFilterByValueFunction,
+		NewKeysMatchFunction,
+		NewMergeStrictFunction,
+		NewRequireSubsetProjectionFunction,
+		NewResolveFullFunction,
+		NewResolveManyFunction,
+		NewResolvedValuesFunction,
+		NewToTableFunction,
+		NewValueDifferenceFunction,
+	}
 }
 
 func (p *Resolver) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -45,12 +123,27 @@ func (p *Resolver) Metadata(ctx context.Context, req provider.MetadataRequest, r
 
 func (p *Resolver) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewListLookupResource,
+		NewListMapResource,
 		NewMapResource,
+		NewStringMapResource,
 	}
 }
 
 func (p *Resolver) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This Terraform provider provides a resource that provides a resolution between keys and values when a subset is unknown to prevent unnessary plan diffs that are no-ops at apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"default_values": schema.MapAttribute{
+				Description: "Values used by resolver_map for keys whose value is null, after value_from_key_regex, and for result_keys that are not found in fallback_source. Keys that are not in this either stay null.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"validate_only": schema.BoolAttribute{
+				Description: "Whether resources should only validate and resolve without persisting their result, which is left unknown at plan and null after apply along with every other output computed from the resolution. Useful to gate plan-only CI runs on validation errors.",
+				Optional:    true,
+			},
+		},
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestInternalProviderDataSources(t *testing.T) {
+	dataSources := New("test")().DataSources(context.Background())
+
+	if dataSources == nil {
+		t.Fatal("Got nil data sources, wanted an empty slice")
+	}
+
+	if len(dataSources) != 0 {
+		t.Errorf("Got %d data sources, wanted 0", len(dataSources))
+	}
+}
+
+func TestInternalProviderResources(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var metadataResp provider.MetadataResponse
+	p.Metadata(ctx, provider.MetadataRequest{}, &metadataResp)
+
+	var typeNames []string
+	for _, newResource := range p.Resources(ctx) {
+		var resp resource.MetadataResponse
+		newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadataResp.TypeName}, &resp)
+		typeNames = append(typeNames, resp.TypeName)
+	}
+
+	expectedTypeNames := []string{"resolver_list_lookup", "resolver_list_map", "resolver_map", "resolver_string_map"}
+	if !reflect.DeepEqual(expectedTypeNames, typeNames) {
+		t.Errorf("Got %+v, wanted %+v", typeNames, expectedTypeNames)
+	}
+}
+
+func TestInternalPrintResult(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		data            *resolverData
+		expectedPrinted bool
+	}{
+		{
+			data:            &resolverData{version: "dev"},
+			expectedPrinted: true,
+		},
+		{
+			data:            &resolverData{version: "test"},
+			expectedPrinted: true,
+		},
+		{
+			data:            &resolverData{version: "1.2.3"},
+			expectedPrinted: false,
+		},
+		// not configured yet
+		{
+			data:            nil,
+			expectedPrinted: false,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.data)
+
+		t.Run(testname, func(t *testing.T) {
+			var output bytes.Buffer
+			test.data.printResult(tflogtest.RootLogger(context.Background(), &output), result)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("Got %+v", err)
+			}
+
+			actualPrinted := len(entries) == 1 && entries[0]["@level"] == "debug" && entries[0]["result"] == result.String()
+			if actualPrinted != test.expectedPrinted {
+				t.Errorf("Got %+v, wanted printed %t", entries, test.expectedPrinted)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.ResourceWithConfigure = (*ListLookupResource)(nil)
+var _ resource.ResourceWithConfigValidators = (*ListLookupResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*ListLookupResource)(nil)
+
+func NewListLookupResource() resource.Resource {
+	return &ListLookupResource{}
+}
+
+type ListLookupResource struct {
+	data *resolverData
+}
+
+func (r *ListLookupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("clamp"),
+			path.MatchRoot("wrap"),
+		),
+	}
+}
+
+func (r *ListLookupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Will be nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resolverData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resolverData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.data = data
+}
+
+func (r *ListLookupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model listLookupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue("-")
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *ListLookupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *ListLookupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_lookup"
+}
+
+func (r *ListLookupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Will be when the resource is being deleted.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var model listLookupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.Plan, false)
+}
+
+// Read does not need to perform any operations as the state in ReadResourceResponse is already populated.
+func (r *ListLookupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *ListLookupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an element of a list by its index, which is known at plan when the index and list are even if other elements are not.",
+
+		Attributes: map[string]schema.Attribute{
+			"clamp": schema.BoolAttribute{
+				Description: "Whether an index outside of values is clamped to the first or last element instead of being an error. Conflicts with wrap.",
+				Optional:    true,
+			},
+			"index": schema.Int64Attribute{
+				Description: "The zero-based index of the element to look up.",
+				Required:    true,
+			},
+			"values": schema.ListAttribute{
+				Description: "The list to look up the element in, whose elements may be unknown.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"wrap": schema.BoolAttribute{
+				Description: "Whether an index outside of values wraps around, so that -1 is the last element and the length of values is the first. Conflicts with clamp.",
+				Optional:    true,
+			},
+
+			// Computed
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static value used internally by Terraform, this should not be referenced in configurations.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"result": schema.StringAttribute{
+				Computed:    true,
+				Description: "The element of values at index. If index, values, clamp or wrap is unknown, this will be unknown.",
+			},
+		},
+	}
+}
+
+func (r *ListLookupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model listLookupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+}
+
+func (r *ListLookupResource) modify(ctx context.Context, model listLookupModel, diagnostics *diag.Diagnostics, state PlanOrState, errorOnUnresolved bool) {
+	if model.Index.IsUnknown() || model.Values.IsUnknown() || model.Clamp.IsUnknown() || model.Wrap.IsUnknown() {
+		model.Result = basetypes.NewStringUnknown()
+	} else {
+		var ok bool
+		model.Result, ok = lookupIndex(model.Values, model.Index.ValueInt64(), model.Clamp.ValueBool(), model.Wrap.ValueBool())
+
+		// The length of a known list is always known, so this can already be reported at plan.
+		if !ok {
+			diagnostics.AddAttributeError(
+				path.Root("index"),
+				"Index is out of bounds",
+				fmt.Sprintf("%d is not an index of values, which has %d elements.", model.Index.ValueInt64(), len(model.Values.Elements())),
+			)
+			return
+		}
+	}
+
+	// Everything has still been validated and resolved, but the result is withheld.
+	if r.data.isValidateOnly() {
+		if errorOnUnresolved {
+			model.Result = basetypes.NewStringNull()
+		} else {
+			model.Result = basetypes.NewStringUnknown()
+		}
+	}
+
+	diagnostics.Append(state.Set(ctx, model)...)
+}
+
+type listLookupModel struct {
+	Clamp  types.Bool   `tfsdk:"clamp"`
+	ID     types.String `tfsdk:"id"`
+	Index  types.Int64  `tfsdk:"index"`
+	Result types.String `tfsdk:"result"`
+	Values types.List   `tfsdk:"values"`
+	Wrap   types.Bool   `tfsdk:"wrap"`
+}
+
+// lookupIndex returns the element of values at index, after clamping or wrapping it when asked to, and false if the
+// index is still outside of values, which it always is for an empty list.
+func lookupIndex(values basetypes.ListValue, index int64, clamp, wrap bool) (basetypes.StringValue, bool) {
+	elements := values.Elements()
+	length := int64(len(elements))
+
+	if length == 0 {
+		return basetypes.NewStringNull(), false
+	}
+
+	if clamp {
+		index = min(max(index, 0), length-1)
+	} else if wrap {
+		index = (index%length + length) % length
+	}
+
+	if index < 0 || index >= length {
+		return basetypes.NewStringNull(), false
+	}
+
+	return elements[index].(basetypes.StringValue), true
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceListLookup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_list_lookup" "test" {
+					index  = 1
+					values = ["a", "b", "c"]
+				}
+
+				resource "resolver_list_lookup" "clamp" {
+					clamp  = true
+					index  = 5
+					values = ["a", "b", "c"]
+				}
+
+				resource "resolver_list_lookup" "wrap" {
+					index  = -1
+					values = ["a", "b", "c"]
+					wrap   = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_list_lookup.test", "result", "b"),
+					resource.TestCheckResourceAttr("resolver_list_lookup.clamp", "result", "c"),
+					resource.TestCheckResourceAttr("resolver_list_lookup.wrap", "result", "c"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceListLookupOutOfBounds(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_list_lookup" "test" {
+					index  = 3
+					values = ["a", "b", "c"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Index is out of bounds)`),
+			},
+		},
+	})
+}
+
+func TestInternalLookupIndex(t *testing.T) {
+	values := basetypes.NewListValueMust(types.StringType, []attr.Value{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringUnknown(),
+		basetypes.NewStringValue("c"),
+	})
+	empty := basetypes.NewListValueMust(types.StringType, []attr.Value{})
+
+	var tests = []struct {
+		values         basetypes.ListValue
+		index          int64
+		clamp, wrap    bool
+		expectedResult basetypes.StringValue
+		expectedOk     bool
+	}{
+		{values: values, index: 0, expectedResult: basetypes.NewStringValue("a"), expectedOk: true},
+		// the element itself may be unknown
+		{values: values, index: 1, expectedResult: basetypes.NewStringUnknown(), expectedOk: true},
+		{values: values, index: 3, expectedResult: basetypes.NewStringNull()},
+		{values: values, index: -1, expectedResult: basetypes.NewStringNull()},
+		{values: values, index: 7, clamp: true, expectedResult: basetypes.NewStringValue("c"), expectedOk: true},
+		{values: values, index: -2, clamp: true, expectedResult: basetypes.NewStringValue("a"), expectedOk: true},
+		{values: values, index: 3, wrap: true, expectedResult: basetypes.NewStringValue("a"), expectedOk: true},
+		{values: values, index: -4, wrap: true, expectedResult: basetypes.NewStringValue("c"), expectedOk: true},
+		{values: empty, index: 0, clamp: true, expectedResult: basetypes.NewStringNull()},
+		{values: empty, index: 0, wrap: true, expectedResult: basetypes.NewStringNull()},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%d,%t,%t", test.values, test.index, test.clamp, test.wrap)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult, actualOk := lookupIndex(test.values, test.index, test.clamp, test.wrap)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) || actualOk != test.expectedOk {
+				t.Errorf("Got %+v %t, wanted %+v %t", actualResult, actualOk, test.expectedResult, test.expectedOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.ResourceWithConfigure = (*ListMapResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*ListMapResource)(nil)
+
+func NewListMapResource() resource.Resource {
+	return &ListMapResource{}
+}
+
+type ListMapResource struct {
+	data *resolverData
+}
+
+func (r *ListMapResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Will be nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resolverData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resolverData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.data = data
+}
+
+func (r *ListMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model listMapModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue("-")
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+}
+
+// Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
+// [framework](https://github.com/hashicorp/terraform-plugin-framework/pull/301).
+func (r *ListMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *ListMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_list_map"
+}
+
+func (r *ListMapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Will be when the resource is being deleted.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var model listMapModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.Plan, false)
+}
+
+// Read does not need to perform any operations as the state in ReadResourceResponse is already populated.
+func (r *ListMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *ListMapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attempts to project a map of lists onto a subset of its keys when possible instead of the entire map being unknown at plan.",
+
+		Attributes: map[string]schema.Attribute{
+			"result_keys": schema.ListAttribute{
+				Description: "The list of keys that should be in the result, must be a subset of the keys of source.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"source": schema.MapAttribute{
+				Description: "The map of lists to project, whose lists may be or contain unknown values.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Required:    true,
+			},
+
+			// Computed
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "A static value used internally by Terraform, this should not be referenced in configurations.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"result": schema.MapAttribute{
+				Computed:    true,
+				Description: "The lists of source for each result key, in their original order. If source or a result_key is unknown, this will be unknown.",
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (r *ListMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model listMapModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+}
+
+func (r *ListMapResource) modify(ctx context.Context, model listMapModel, diagnostics *diag.Diagnostics, state PlanOrState, errorOnUnresolved bool) {
+	resultKeys := make([]basetypes.StringValue, len(model.ResultKeys.Elements()))
+	diagnostics.Append(model.ResultKeys.ElementsAs(ctx, &resultKeys, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	var missing []string
+	model.Result, missing = projectListMap(model.Source, resultKeys)
+
+	// The keys of a known map are always known, so missing result keys can already be reported at plan.
+	if len(missing) > 0 {
+		diagnostics.AddAttributeError(
+			path.Root("result_keys"),
+			"Unable to resolve some result_keys, is it a subset of the keys of source?",
+			fmt.Sprintf("%s are not keys of source.", strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	// Everything has still been validated and resolved, but the result is withheld.
+	if r.data.isValidateOnly() {
+		if errorOnUnresolved {
+			model.Result = basetypes.NewMapNull(types.ListType{ElemType: types.StringType})
+		} else {
+			model.Result = basetypes.NewMapUnknown(types.ListType{ElemType: types.StringType})
+		}
+	}
+
+	diagnostics.Append(state.Set(ctx, model)...)
+}
+
+type listMapModel struct {
+	ID         types.String `tfsdk:"id"`
+	Result     types.Map    `tfsdk:"result"`
+	ResultKeys types.List   `tfsdk:"result_keys"`
+	Source     types.Map    `tfsdk:"source"`
+}
+
+// projectListMap returns the entries of source for each result key, keeping their lists as they are, including any
+// unknown elements. It is unknown while source or any result key is unknown, and null with the quoted result keys that
+// are not in source if there are any.
+func projectListMap(source basetypes.MapValue, resultKeys []basetypes.StringValue) (basetypes.MapValue, []string) {
+	listType := types.ListType{ElemType: types.StringType}
+
+	if source.IsUnknown() {
+		return basetypes.NewMapUnknown(listType), nil
+	}
+
+	var missing []string
+	projection := make(map[string]attr.Value)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsUnknown() {
+			return basetypes.NewMapUnknown(listType), nil
+		}
+
+		list, ok := source.Elements()[resultKey.ValueString()]
+		if !ok {
+			missing = append(missing, strconv.Quote(resultKey.ValueString()))
+			continue
+		}
+
+		projection[resultKey.ValueString()] = list
+	}
+
+	if len(missing) > 0 {
+		return basetypes.NewMapNull(listType), missing
+	}
+
+	return basetypes.NewMapValueMust(listType, projection), nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceListMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_list_map" "test" {
+					result_keys = ["a", "c"]
+					source = {
+						a = ["1", "2"]
+						b = ["3"]
+						c = []
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_list_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_list_map.test", "result.a.#", "2"),
+					resource.TestCheckResourceAttr("resolver_list_map.test", "result.a.0", "1"),
+					resource.TestCheckResourceAttr("resolver_list_map.test", "result.a.1", "2"),
+					resource.TestCheckResourceAttr("resolver_list_map.test", "result.c.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceListMapMissingResultKey(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_list_map" "test" {
+					result_keys = ["a", "d"]
+					source = {
+						a = ["1"]
+					}
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Unable to resolve some result_keys)`),
+			},
+		},
+	})
+}
+
+func TestInternalProjectListMap(t *testing.T) {
+	listType := types.ListType{ElemType: types.StringType}
+	list := func(values ...attr.Value) attr.Value {
+		return basetypes.NewListValueMust(types.StringType, values)
+	}
+
+	source := basetypes.NewMapValueMust(listType, map[string]attr.Value{
+		"a": list(basetypes.NewStringValue("1"), basetypes.NewStringUnknown(), basetypes.NewStringValue("3")),
+		"b": basetypes.NewListUnknown(types.StringType),
+		"c": list(basetypes.NewStringValue("4")),
+	})
+
+	var tests = []struct {
+		source          basetypes.MapValue
+		resultKeys      []basetypes.StringValue
+		expectedResult  basetypes.MapValue
+		expectedMissing []string
+	}{
+		// unknown elements and lists are preserved in order
+		{
+			source: source,
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			expectedResult: basetypes.NewMapValueMust(listType, map[string]attr.Value{
+				"a": list(basetypes.NewStringValue("1"), basetypes.NewStringUnknown(), basetypes.NewStringValue("3")),
+				"b": basetypes.NewListUnknown(types.StringType),
+			}),
+		},
+		// result key is unknown
+		{
+			source: source,
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapUnknown(listType),
+		},
+		// source is unknown
+		{
+			source: basetypes.NewMapUnknown(listType),
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			expectedResult: basetypes.NewMapUnknown(listType),
+		},
+		// result key is not in source
+		{
+			source: source,
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("d"),
+			},
+			expectedResult:  basetypes.NewMapNull(listType),
+			expectedMissing: []string{`"d"`},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.source, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult, actualMissing := projectListMap(test.source, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) || !reflect.DeepEqual(test.expectedMissing, actualMissing) {
+				t.Errorf("Got %+v %+v, wanted %+v %+v", actualResult, actualMissing, test.expectedResult, test.expectedMissing)
+			}
+		})
+	}
+}
@@ -1,8 +1,28 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -10,10 +30,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/yaml.v3"
 )
 
+var _ resource.ResourceWithConfigValidators = (*MapResource)(nil)
+var _ resource.ResourceWithConfigure = (*MapResource)(nil)
+var _ resource.ResourceWithImportState = (*MapResource)(nil)
 var _ resource.ResourceWithModifyPlan = (*MapResource)(nil)
 
 func NewMapResource() resource.Resource {
@@ -24,21 +51,43 @@ type PlanOrState interface {
 	Set(context.Context, interface{}) diag.Diagnostics
 }
 
-type MapResource struct{}
+type MapResource struct {
+	data *resolverData
+}
 
-func (r *MapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var model mapModel
+func (r *MapResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("conditional_result_keys"),
+			path.MatchRoot("result_keys"),
+		),
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("key_source"),
+			path.MatchRoot("keys"),
+		),
+	}
+}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+func (r *MapResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Will be nil until the provider has been configured.
+	if req.ProviderData == nil {
+		return
+	}
 
-	if resp.Diagnostics.HasError() {
+	data, ok := req.ProviderData.(*resolverData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resolverData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
 		return
 	}
 
-	model.ID = types.StringValue("-")
+	r.data = data
+}
 
-	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+func (r *MapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.applyMap(ctx, req.Plan, nil, nil, &resp.State, resp.Private, &resp.Diagnostics, readMap)
 }
 
 // Delete does not need to explicitly call resp.State.RemoveResource() as this is automatically handled by the
@@ -46,30 +95,36 @@ func (r *MapResource) Create(ctx context.Context, req resource.CreateRequest, re
 func (r *MapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
 
-func (r *MapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_map"
-}
-
-func (r *MapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Will be when the resource is being deleted.
-	if req.Plan.Raw.IsNull() {
+// ImportState takes a JSON object of strings as the ID, which becomes the result, with each of its keys as both a key
+// and a result key.
+func (r *MapResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	keys, values, result, err := importedMap(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("The ID must be a JSON object whose values are strings: %s.", err),
+		)
 		return
 	}
 
-	var model mapModel
-
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "-")...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("keys"), keys)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("result"), result)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("result_keys"), keys)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("values"), values)...)
+}
 
-	if resp.Diagnostics.HasError() {
-		return
-	}
+func (r *MapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_map"
+}
 
-	r.modify(ctx, model, &resp.Diagnostics, &resp.Plan, false)
+func (r *MapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.planMap(ctx, req, resp, readMap)
 }
 
-// Read does not need to perform any operations as the state in ReadResourceResponse is already populated.
+// Read keeps the state in ReadResourceResponse as it is, apart from the outputs that refreshOutputs completes.
 func (r *MapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	refreshOutputs(ctx, &resp.State, &resp.Diagnostics)
 }
 
 func (r *MapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -77,23 +132,300 @@ func (r *MapResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 		MarkdownDescription: "Attempts to resolve a map when possible instead of the entire map being unknown at plan.",
 
 		Attributes: map[string]schema.Attribute{
+			"aliases": schema.MapAttribute{
+				Description: "A mapping of alias to canonical key, so that a result key given as an alias resolves to the value of its canonical key. Aliases may refer to other aliases, but not in a cycle. The result uses the result key as given.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"blank_is_null": schema.BoolAttribute{
+				Description: "Whether values that are empty or only whitespace should be treated as null, for upstream systems that use them to mean absent.",
+				Optional:    true,
+			},
+			"chunk_size": schema.Int64Attribute{
+				Description: "The number of pairs in each list of result_chunks.",
+				Optional:    true,
+			},
+			"coerce_result_keys": schema.BoolAttribute{
+				Description: "Whether keys and result_keys that are numbers match by their canonical form, so that `1` matches `1.0` or `01`. The result uses the result key as given.",
+				Optional:    true,
+			},
+			"collect_errors": schema.BoolAttribute{
+				Description: "Whether validation problems should be listed in errors alongside a best-effort result instead of failing the plan or apply.",
+				Optional:    true,
+			},
+			"compute_complement": schema.BoolAttribute{
+				Description: "Whether complement is computed.",
+				Optional:    true,
+			},
+			"conditional_result_keys": schema.ListAttribute{
+				Description: "An alternative to result_keys where each key is only in the result when its include is true. If an include is unknown, the result will be unknown.",
+				ElementType: conditionalResultKeyType,
+				Optional:    true,
+			},
+			"decode_before_encode": schema.BoolAttribute{
+				Description: "Whether each value in result is base64 decoded before being encoded with encode_values, to transcode between encodings.",
+				Optional:    true,
+			},
+			"default_value": schema.StringAttribute{
+				Description: "The value of result_keys that are neither in keys, inherit_from nor fallback_source, rather than them being an error. result_without_defaults leaves these entries out.",
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of what the resource resolves, stored in state as is for documentation. It has no effect on resolution.",
+				Optional:    true,
+			},
+			"encode_values": schema.StringAttribute{
+				Description: "The encoding applied to each value in result, one of \"none\" (the default), \"base64\", \"base64url\" or \"hex\". Unknown values stay unknown.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("base64", "base64url", "hex", "none"),
+				},
+			},
+			"error_if_unknown_after": schema.StringAttribute{
+				Description: "An RFC 3339 timestamp after which applying with result_keys that did not resolve is an error, even when max_unknowns, keep_last_good or collect_errors would otherwise tolerate them. Useful to require resolution to be complete by a deadline.",
+				Optional:    true,
+			},
+			"expected_result": schema.MapAttribute{
+				Description: "The mapping result is expected to be. A known entry of result that differs is warned about at plan, and any difference is an error at apply.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"fail_on_empty_result": schema.BoolAttribute{
+				Description: "Whether an empty result is an error at apply, a stricter plan_warning_on_empty_result for when no result_keys resolving must not go unnoticed.",
+				Optional:    true,
+			},
+			"fallback_source": schema.MapAttribute{
+				Description: "A mapping consulted for result_keys that are neither in keys nor inherit_from. Result keys that are not in it either resolve to the provider default_values, or to null, rather than being an error.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"forbidden_values": schema.SetAttribute{
+				Description: "Values that are an error when a key has them, such as placeholders like \"CHANGEME\" that should not leak through. Unknown and null values are exempt.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"inherit_from": schema.MapAttribute{
+				Description: "A base mapping, such as the result of another resolver_map, whose values are used for result_keys that are not in keys. Values from keys and values take precedence over inherited ones.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"keep_last_good": schema.BoolAttribute{
+				Description: "Whether the result in the prior state is kept when some result_keys can no longer be resolved, with a warning instead of an error.",
+				Optional:    true,
+			},
+			"key_normalization": schema.ListAttribute{
+				Description: "Transforms applied in order to keys and result_keys before they are matched, any of \"trim\", \"lower\" or \"nfc\" (Unicode normalization form C). The result is keyed by the normalized result keys.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"key_source": schema.MapAttribute{
+				Description: "An alternative to keys for when the keys are themselves computed, where the keys are the values of this map in the order of its keys sorted lexicographically. If it is unknown, every key is unknown.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"key_validation_message": schema.StringAttribute{
+				Description: "The error reported for keys that do not match key_validation_regex. Defaults to \"Invalid key\".",
+				Optional:    true,
+			},
+			"key_validation_regex": schema.StringAttribute{
+				Description: "A regular expression that every known key must match, otherwise it is an error with key_validation_message.",
+				Optional:    true,
+			},
 			"keys": schema.ListAttribute{
-				Description: "The list of keys, must be in same order as values.",
+				Description: "The list of keys, must be in same order as values. Exactly one of keys or key_source must be set.",
 				ElementType: types.StringType,
-				Required:    true,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(0),
+				},
+			},
+			"max_keys": schema.Int64Attribute{
+				Description: "The most entries result may have, otherwise it is an error.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_unknowns": schema.Int64Attribute{
+				Description: "The number of result_keys that may be left unresolved at apply, which are then left out of result. By default, every result key must resolve.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_keys": schema.Int64Attribute{
+				Description: "The fewest entries result may have, otherwise it is an error.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"normalize_durations": schema.BoolAttribute{
+				Description: "Whether each value in result is rewritten in the canonical form of a Go duration, such as \"1m30s\" for \"90s\". Implies value_is_duration.",
+				Optional:    true,
+			},
+			"order_by": schema.StringAttribute{
+				Description: "The order of result_pairs when result_keys_order is not set, either \"key\" to sort by key (the default), \"keys_input\" to follow keys or \"value\" to sort by value. Unlike result_keys_order, these do not depend on the order of result_keys. Result keys that compare equal stay in result_keys order.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("key", "keys_input", "value"),
+					stringvalidator.ConflictsWith(path.MatchRoot("result_keys_order")),
+				},
+			},
+			"overwrite_keys": schema.MapAttribute{
+				Description: "Values that override the resolved value of each of their keys in the result, including when it is unknown or not in keys.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"parse_values_as": schema.StringAttribute{
+				Description: "The type, either \"number\" or \"bool\", that each value in result is parsed as for parsed_result.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("bool", "number"),
+				},
+			},
+			"plan_warning_on_empty_result": schema.BoolAttribute{
+				Description: "Whether an empty result is warned about at plan, which usually means that no result_keys are in keys.",
+				Optional:    true,
+			},
+			"print_result": schema.BoolAttribute{
+				Description: "Whether result is written to the provider log at debug level once resolved, for local debugging. Only has an effect in development builds of the provider.",
+				Optional:    true,
+			},
+			"replace_in_keys": schema.ListAttribute{
+				Description: "Find-and-replace rules applied in order to each result key, renaming it in result and the attributes derived from it. Rules with an empty from are skipped. Two result keys becoming the same key is an error.",
+				ElementType: replacementType,
+				Optional:    true,
+			},
+			"replace_in_values": schema.ListAttribute{
+				Description: "Find-and-replace rules applied in order to each value in result, each replacing every occurrence of from with to. Rules with an empty from are skipped.",
+				ElementType: replacementType,
+				Optional:    true,
+			},
+			"result_groups": schema.MapAttribute{
+				Description: "Named groups of result keys, each resolved into its own map in result_by_group, for producing several maps for different consumers from one resource.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+			"result_key_validation_regex": schema.StringAttribute{
+				Description: "A regular expression that every known result key must match, such as to catch generated result_keys with characters the target system does not allow.",
+				Optional:    true,
 			},
 			"result_keys": schema.ListAttribute{
-				Description: "The list of keys that should be in the result, must be a subset of keys.",
+				DeprecationMessage: "Use result_groups with a single group instead, and read its map from result_by_group. result_keys will be removed in a future release.",
+				Description:        "The list of keys that should be in the result, must be a subset of keys. When neither this nor conditional_result_keys is set, every key is in the result, whereas an empty list gives an empty result.",
+				ElementType:        types.StringType,
+				Optional:           true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(0),
+				},
+			},
+			"result_keys_dedup": schema.BoolAttribute{
+				Description: "Whether duplicate result_keys are expected and should be silently deduplicated, otherwise they are warned about at plan.",
+				Optional:    true,
+			},
+			"result_keys_order": schema.StringAttribute{
+				Description: "The order of result_pairs relative to result_keys, either \"input\" to follow result_keys, \"lexicographic\" to sort by key or \"reverse\" to reverse result_keys. When set, this is used instead of order_by.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("input", "lexicographic", "reverse"),
+				},
+			},
+			"result_template": schema.StringAttribute{
+				Description: "A Go text/template rendered into result_rendered, where {{.key}} is replaced by the value of key in result. Every key it references must be in result_keys.",
+				Optional:    true,
+			},
+			"stable_result": schema.BoolAttribute{
+				Description: "Whether entries of result that become unknown at plan keep their value from the prior state until they are known again, rather than showing as unknown.",
+				Optional:    true,
+			},
+			"strip_result_key_prefix": schema.StringAttribute{
+				Description: "A prefix removed from each result key in result and the attributes derived from it, after replace_in_keys. Keys are still matched as given. Two result keys becoming the same key is an error.",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Organizational metadata, such as the owning team, that is stored in state for monitoring tools and passed through to result_tags. It has no effect on resolution.",
 				ElementType: types.StringType,
-				Required:    true,
+				Optional:    true,
+			},
+			"transforms": schema.MapAttribute{
+				Description: "A transform applied to the value of each of its result keys, any of \"upper\", \"lower\", \"trim\" or \"base64encode\". Values of other result keys are left as they are.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf("base64encode", "lower", "trim", "upper")),
+				},
+			},
+			"trim_prefix": schema.StringAttribute{
+				Description: "A prefix removed from each value in result that starts with it.",
+				Optional:    true,
+			},
+			"trim_suffix": schema.StringAttribute{
+				Description: "A suffix removed from each value in result that ends with it.",
+				Optional:    true,
+			},
+			"unknown_ini_value": schema.StringAttribute{
+				Description: "The placeholder written to result_ini for values that are unknown. Defaults to \"__UNKNOWN__\".",
+				Optional:    true,
+			},
+			"unknown_placeholder": schema.StringAttribute{
+				Description: "The placeholder used in result_safe for values that are unknown. Defaults to \"__UNKNOWN__\".",
+				Optional:    true,
+			},
+			"value_from_key_regex": schema.StringAttribute{
+				Description: "A regular expression used to derive the value of each key whose value is null, by replacing its matches in the key with value_replace. Values of keys that do not match stay null.",
+				Optional:    true,
+			},
+			"value_is_duration": schema.BoolAttribute{
+				Description: "Whether each known value in result must parse as a Go duration, such as \"30s\" or \"1h5m\". Null values are exempt.",
+				Optional:    true,
+			},
+			"value_replace": schema.StringAttribute{
+				Description: "The replacement for matches of value_from_key_regex, where $1 or ${name} expand to capture groups. Defaults to the whole match.",
+				Optional:    true,
+			},
+			"value_validation_message": schema.StringAttribute{
+				Description: "The error reported for values that do not match value_validation_regex. Defaults to \"Invalid value\".",
+				Optional:    true,
+			},
+			"value_validation_regex": schema.StringAttribute{
+				Description: "A regular expression that every known value must match, otherwise it is an error with value_validation_message. Null values are not validated.",
+				Optional:    true,
 			},
 			"values": schema.ListAttribute{
-				Description: "The list of values, must be in same order as keys.",
+				Description: "The list of values, must be in same order as keys. A null value stays null in result unless derived with value_from_key_regex.",
 				ElementType: types.StringType,
 				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(0),
+				},
+			},
+			"warn_on_shadow": schema.BoolAttribute{
+				Description: "Whether keys that replace a different value given before them, earlier in keys or in inherit_from, are warned about at plan, to surface unintended overrides.",
+				Optional:    true,
 			},
 
 			// Computed
+			"changed_keys": schema.ListAttribute{
+				Computed:    true,
+				Description: "The sorted keys whose entry in result was changed by the last create or update that changed it, including keys that were added or removed. A key whose value was unknown at plan is always included. If result is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"complement": schema.MapAttribute{
+				Computed:    true,
+				Description: "The entries of keys and values whose key is not a result key, to split them into two maps along with result. Null unless compute_complement is true. If a key or result_key is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"defaulted_keys": schema.ListAttribute{
+				Computed:    true,
+				Description: "The result keys that fell back to default_value, in the order of result_keys. Empty when default_value is not set. If a result_key is unknown, or a key is unknown while some result keys would be defaulted, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"errors": schema.ListAttribute{
+				Computed:    true,
+				Description: "The validation problems found when collect_errors is enabled, otherwise null.",
+				ElementType: types.StringType,
+			},
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "A static value used internally by Terraform, this should not be referenced in configurations.",
@@ -101,127 +433,3256 @@ func (r *MapResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"key_index": schema.MapAttribute{
+				Computed:    true,
+				Description: "The zero-based position in keys of each result key, as a string. Result keys that are not in keys are left out, and a position that depends on an unknown key will be unknown.",
+				ElementType: types.StringType,
+			},
+			"key_positions": schema.ListAttribute{
+				Computed:    true,
+				Description: "The zero-based position in keys of each of result_keys, in the same order. The position of a result key that is not in keys is null, and one that depends on an unknown key will be unknown.",
+				ElementType: types.Int64Type,
+			},
+			"known_result": schema.MapAttribute{
+				Computed:    true,
+				Description: "The entries of result whose values are known, which is never unknown so that they can be used straight away, and empty while result is null or unknown. The entries that were planned are kept at apply, as Terraform requires, and completed with the applied values on the next refresh.",
+				ElementType: types.StringType,
+			},
+			"last_modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp of the create or update that last changed result. It is unknown at plan when result changes.",
+			},
+			"null_safe_result": schema.MapAttribute{
+				Computed:    true,
+				Description: "The result with null values replaced by empty strings, for functions that do not handle null values in maps. If result is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"parsed_result": schema.DynamicAttribute{
+				Computed:    true,
+				Description: "The result with each value parsed as parse_values_as, as a map of that type. Null when parse_values_as is not set, and unknown when result is unknown.",
+			},
+			"resolved_flags": schema.MapAttribute{
+				Computed:    true,
+				Description: "Whether each result key resolved to a known value, false when it is not in keys. A flag that depends on an unknown key or value will be unknown, and if a result_key is unknown, this will be unknown.",
+				ElementType: types.BoolType,
+			},
 			"result": schema.MapAttribute{
 				Computed:    true,
 				Description: "The resolved mapping. If a result_key is unknown, this will be unknown.",
 				ElementType: types.StringType,
 			},
+			"result_as_list": schema.ListAttribute{
+				Computed:    true,
+				Description: "The key and value of each entry in result, in the order of result_keys, named after the convention of other providers. An unknown value has an unknown value field. If result is unknown, this will be unknown.",
+				ElementType: resultPairType,
+			},
+			"result_by_group": schema.MapAttribute{
+				Computed:    true,
+				Description: "The resolved map for each group in result_groups, resolved as result is from keys and values. Null when result_groups is not set. If result_groups is unknown, this will be unknown, and each group map is unknown or null as result would be.",
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"result_chunks": schema.ListAttribute{
+				Computed:    true,
+				Description: "The result_pairs split into lists of chunk_size pairs, with any remainder in the last list. Null when chunk_size is not set.",
+				ElementType: types.ListType{ElemType: resultPairType},
+			},
+			"result_count": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"missing": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of result keys that are not in keys.",
+					},
+					"resolved": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of result keys that resolved to a known value.",
+					},
+					"total": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of distinct result keys.",
+					},
+					"unknown": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of result keys whose value is unknown or that may be one of the unknown keys.",
+					},
+				},
+				Computed:    true,
+				Description: "A summary of how many result keys resolved. If a result_key is unknown, this will be unknown.",
+			},
+			"result_csv": schema.StringAttribute{
+				Computed:    true,
+				Description: "The result as CSV with a key,value header and a row per entry sorted by key. If result or any of its values is unknown, this will be unknown.",
+			},
+			"result_env_pairs": schema.ListAttribute{
+				Computed:    true,
+				Description: "Each entry in result as KEY=value, sorted by key, as used for environment variables. An entry whose value is unknown will be unknown, and if result is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"result_go_map": schema.MapAttribute{
+				Computed:    true,
+				Description: "The same value as result under another name, for referencing it in expressions that already use result.",
+				ElementType: types.StringType,
+			},
+			"result_ini": schema.StringAttribute{
+				Computed:    true,
+				Description: "The result as key = value lines sorted by key, without sections. Backslashes, line breaks and, in keys, equals signs are escaped with a backslash, so that no entry can add lines of its own. Unknown values are written as unknown_ini_value, and if result is unknown, this will be unknown.",
+			},
+			"result_json_path": schema.StringAttribute{
+				Computed:    true,
+				Description: "A JSONPath expression for each result key, such as $.key, separated by semicolons, for tools that query the result as a JSON object. Known whenever result_keys are, even if values are not.",
+			},
+			"result_json_schema": schema.StringAttribute{
+				Computed:    true,
+				Description: "A JSON Schema describing result as an object with a required string property for each result key. Known whenever result_keys are, even if values are not.",
+			},
+			"result_keys_found": schema.ListAttribute{
+				Computed:    true,
+				Description: "The result keys that are known to be in keys, in the order of result_keys, regardless of whether their values are known. If a result_key is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"result_keys_not_found": schema.ListAttribute{
+				Computed:    true,
+				Description: "The result keys that are known not to be in keys, in the order of result_keys. If a result_key is unknown, or a key is unknown while some result keys are not found, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"result_pairs": schema.ListAttribute{
+				Computed:    true,
+				Description: "The key and value of each entry in result, ordered by result_keys_order or order_by, which sort by key by default. If result is unknown, this will be unknown.",
+				ElementType: resultPairType,
+			},
+			"result_rendered": schema.StringAttribute{
+				Computed:    true,
+				Description: "The result_template rendered with result. If a value it references is unknown, this will be unknown. Null when result_template is not set.",
+			},
+			"result_safe": schema.MapAttribute{
+				Computed:    true,
+				Description: "The result with unknown values replaced by unknown_placeholder, for consumers that cannot handle unknown values. Placeholders that were planned are kept at apply, as Terraform requires, and replaced with the applied values on the next refresh. If result is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"result_size_matches_result_keys": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether result has an entry for every distinct result key, which is false when some were left out of a partial result or result is null. Entries with unknown values still count. If result is unknown, this will be unknown.",
+			},
+			"result_tags": schema.MapAttribute{
+				Computed:    true,
+				Description: "The tags, passed through as they are.",
+				ElementType: types.StringType,
+			},
+			"result_without_defaults": schema.MapAttribute{
+				Computed:    true,
+				Description: "The result without the entries that fell back to default_value, to tell actually resolved entries apart. Null when default_value is not set, and unknown while result is.",
+				ElementType: types.StringType,
+			},
+			"result_yaml": schema.StringAttribute{
+				Computed:    true,
+				Description: "The result as a YAML mapping sorted by key, where null values are null. If result or any of its values is unknown, this will be unknown.",
+			},
+			"unresolved_reasons": schema.MapAttribute{
+				Computed:    true,
+				Description: "Why each result key that did not resolve to a known value did not: \"missing\" when it is not in keys, \"key_unknown\" when it may be one of the unknown keys or \"value_unknown\" when its value is unknown. If a result_key is unknown, this will be unknown.",
+				ElementType: types.StringType,
+			},
+			"version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of times result has changed, starting at 1 when created. It is unknown until result is.",
+			},
 		},
 	}
 }
 
 func (r *MapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.applyMap(ctx, req.Plan, &req.State, req.Private, &resp.State, resp.Private, &resp.Diagnostics, readMap)
+}
+
+// mapReader reads the mapModel out of plan, along with the PlanOrState to set it on in place of target. This is what
+// differs between resolver_map and resolver_string_map, which otherwise share their lifecycle.
+type mapReader func(ctx context.Context, plan tfsdk.Plan, target PlanOrState, diagnostics *diag.Diagnostics) (mapModel, PlanOrState)
+
+// readMap is the mapReader of resolver_map, whose attributes are exactly those of mapModel.
+func readMap(ctx context.Context, plan tfsdk.Plan, target PlanOrState, diagnostics *diag.Diagnostics) (mapModel, PlanOrState) {
 	var model mapModel
 
 	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	diagnostics.Append(plan.Get(ctx, &model)...)
+
+	return model, target
+}
+
+// planMap resolves the plan and tracks whether the result changes.
+func (r *MapResource) planMap(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, read mapReader) {
+	// Will be when the resource is being deleted.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	model, plan := read(ctx, req.Plan, &resp.Plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := priorResult(ctx, req.State, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modify(ctx, model, prior, &resp.Diagnostics, plan, false)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	r.modify(ctx, model, &resp.Diagnostics, &resp.State, true)
+	trackChanges(ctx, &resp.Plan, &req.State, req.Private, basetypes.NewStringUnknown(), &resp.Diagnostics)
 }
 
-func (r *MapResource) modify(ctx context.Context, model mapModel, diagnostics *diag.Diagnostics, state PlanOrState, errorOnUnresolved bool) {
-	keys := make([]basetypes.StringValue, len(model.Keys.Elements()))
-	diagnostics.Append(model.Keys.ElementsAs(ctx, &keys, false)...)
+// applyMap resolves the plan into state on create and update, and stores the hash of the result in private state.
+// priorState and priorPrivate are nil on create.
+func (r *MapResource) applyMap(ctx context.Context, plan tfsdk.Plan, priorState *tfsdk.State, priorPrivate privateStateGetter, state *tfsdk.State, private privateStateSetter, diagnostics *diag.Diagnostics, read mapReader) {
+	model, target := read(ctx, plan, state, diagnostics)
+
 	if diagnostics.HasError() {
 		return
 	}
 
-	resultKeys := make([]basetypes.StringValue, len(model.ResultKeys.Elements()))
-	diagnostics.Append(model.ResultKeys.ElementsAs(ctx, &resultKeys, false)...)
+	prior := basetypes.NewMapNull(types.StringType)
+	if priorState == nil {
+		model.ID = types.StringValue("-")
+	} else {
+		prior = priorResult(ctx, *priorState, diagnostics)
+	}
+
 	if diagnostics.HasError() {
 		return
 	}
 
-	values := make([]basetypes.StringValue, len(model.Values.Elements()))
-	diagnostics.Append(model.Values.ElementsAs(ctx, &values, false)...)
+	r.modify(ctx, model, prior, diagnostics, target, true)
+	checkDeadline(ctx, state, time.Now(), diagnostics)
+
 	if diagnostics.HasError() {
 		return
 	}
 
-	if len(keys) > len(values) {
-		diagnostics.AddAttributeError(path.Root("keys"), "Key count is higher than the number of values", "")
-		diagnostics.AddAttributeError(path.Root("values"), "Value count is lower than the number of keys", "")
-		return
-	} else if len(keys) < len(values) {
-		diagnostics.AddAttributeError(path.Root("keys"), "Key count is lower than the number of values", "")
-		diagnostics.AddAttributeError(path.Root("values"), "Value count is higher than the number of keys", "")
-		return
-	} else if len(resultKeys) > len(keys) {
-		diagnostics.AddAttributeError(path.Root("result_keys"), "Result key count is higher than the number of keys", "")
-		return
+	hash := trackChanges(ctx, state, priorState, priorPrivate, appliedAt(), diagnostics)
+	diagnostics.Append(private.SetKey(ctx, privateStateKeyResultHash, hash)...)
+}
+
+// priorResult reads the result from the prior state, which is null when there is none.
+func priorResult(ctx context.Context, state tfsdk.State, diagnostics *diag.Diagnostics) basetypes.MapValue {
+	prior := basetypes.NewMapNull(types.StringType)
+
+	if !state.Raw.IsNull() {
+		diagnostics.Append(state.GetAttribute(ctx, path.Root("result"), &prior)...)
 	}
 
-	model.Result = resolveMap(keys, resultKeys, values)
+	return prior
+}
+
+// privateStateKeyResultHash is the key in private state of the hash of the result that was last applied.
+const privateStateKeyResultHash = "result_hash"
 
-	if errorOnUnresolved {
-		if model.Result.IsNull() || model.Result.IsUnknown() {
-			diagnostics.AddError("Unable to resolve some result_keys, is it a subset of keys?", "")
-			return
-		}
+type attributeAccessor interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+	SetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}
+
+// refreshOutputs recomputes the outputs that keep what was planned at apply from the result that was applied, so that
+// placeholders for values that were unknown at plan do not outlive the apply.
+func refreshOutputs(ctx context.Context, state attributeAccessor, diagnostics *diag.Diagnostics) {
+	var result basetypes.MapValue
+	diagnostics.Append(state.GetAttribute(ctx, path.Root("result"), &result)...)
+
+	// Withheld under validate_only, or not yet applied.
+	if diagnostics.HasError() || result.IsNull() || result.IsUnknown() {
+		return
 	}
 
-	diagnostics.Append(state.Set(ctx, model)...)
+	// The applied result has no unknown values, so there is nothing left to leave out or for the placeholder to replace.
+	diagnostics.Append(state.SetAttribute(ctx, path.Root("known_result"), knownResult(result))...)
+	diagnostics.Append(state.SetAttribute(ctx, path.Root("result_safe"), safeResult(result, basetypes.NewStringNull()))...)
 }
 
-type mapModel struct {
-	ID         types.String `tfsdk:"id"`
-	Keys       types.List   `tfsdk:"keys"`
-	Result     types.Map    `tfsdk:"result"`
-	ResultKeys types.List   `tfsdk:"result_keys"`
-	Values     types.List   `tfsdk:"values"`
+type privateStateGetter interface {
+	GetKey(context.Context, string) ([]byte, diag.Diagnostics)
 }
 
-func resolveMap(keys, resultKeys, values []basetypes.StringValue) basetypes.MapValue {
-	keyValueMapping := make(map[string]string)
-	keyValueUnknown := make(map[string]bool)
-	keysUnknown := 0
-	resultKeyMapping := make(map[string]bool)
-	resultKeysUnknown := 0
+type privateStateSetter interface {
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}
 
-	for i := 0; i < len(keys); i++ {
-		if keys[i].IsUnknown() {
-			keysUnknown += 1
-			continue
-		}
+// trackChanges sets the version and last_modified of target from whether its result differs from the one last
+// applied, keeps the changed_keys of the last change when there are none now, and returns the hash of its result to
+// store in private state. timestamp is when the result is being changed, which is unknown at plan. prior and private
+// are nil on create.
+func trackChanges(ctx context.Context, target attributeAccessor, prior *tfsdk.State, private privateStateGetter, timestamp basetypes.StringValue, diagnostics *diag.Diagnostics) []byte {
+	var result basetypes.MapValue
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("result"), &result)...)
 
-		if values[i].IsUnknown() {
-			keyValueUnknown[keys[i].ValueString()] = true
-		} else {
-			keyValueMapping[keys[i].ValueString()] = values[i].ValueString()
-		}
-	}
+	var changedKeys basetypes.ListValue
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("changed_keys"), &changedKeys)...)
 
-	for _, resultKey := range resultKeys {
-		if resultKey.IsUnknown() {
-			return basetypes.NewMapUnknown(basetypes.StringType{})
-		}
+	priorChangedKeys := basetypes.NewListNull(types.StringType)
+	priorLastModified := basetypes.NewStringNull()
+	priorVersion := basetypes.NewInt64Null()
+	var priorHash []byte
+
+	if prior != nil && !prior.Raw.IsNull() {
+		diagnostics.Append(prior.GetAttribute(ctx, path.Root("changed_keys"), &priorChangedKeys)...)
+		diagnostics.Append(prior.GetAttribute(ctx, path.Root("last_modified"), &priorLastModified)...)
+		diagnostics.Append(prior.GetAttribute(ctx, path.Root("version"), &priorVersion)...)
 
-		resultKeyMapping[resultKey.ValueString()] = true
+		var getDiagnostics diag.Diagnostics
+		priorHash, getDiagnostics = private.GetKey(ctx, privateStateKeyResultHash)
+		diagnostics.Append(getDiagnostics...)
 	}
 
-	finalMapping := make(map[string]attr.Value)
+	if diagnostics.HasError() {
+		return nil
+	}
 
-	for resultKey := range resultKeyMapping {
-		if value, ok := keyValueMapping[resultKey]; ok {
-			finalMapping[resultKey] = basetypes.NewStringValue(value)
-		} else if _, ok := keyValueUnknown[resultKey]; ok {
-			finalMapping[resultKey] = basetypes.NewStringUnknown()
-		} else {
-			resultKeysUnknown += 1
-		}
+	hash := resultHash(result)
+	version := nextVersion(hash, priorHash, priorVersion)
+
+	lastModified := timestamp
+	if version.IsUnknown() {
+		lastModified = basetypes.NewStringUnknown()
+	} else if version.Equal(priorVersion) && !priorLastModified.IsNull() {
+		lastModified = priorLastModified
 	}
 
-	if resultKeysUnknown > 0 {
-		if resultKeysUnknown <= keysUnknown {
-			return basetypes.NewMapUnknown(basetypes.StringType{})
-		} else {
+	// As changed_keys is about the last change, an update that changes nothing leaves it as it was.
+	if !changedKeys.IsUnknown() && len(changedKeys.Elements()) == 0 && !priorChangedKeys.IsNull() {
+		diagnostics.Append(target.SetAttribute(ctx, path.Root("changed_keys"), priorChangedKeys)...)
+	}
+
+	diagnostics.Append(target.SetAttribute(ctx, path.Root("last_modified"), lastModified)...)
+	diagnostics.Append(target.SetAttribute(ctx, path.Root("version"), version)...)
+
+	return hash
+}
+
+// checkDeadline reads the deadline and the result keys that did not resolve from target, for unresolvedAfterDeadline.
+func checkDeadline(ctx context.Context, target attributeAccessor, now time.Time, diagnostics *diag.Diagnostics) {
+	if diagnostics.HasError() {
+		return
+	}
+
+	var deadline basetypes.StringValue
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("error_if_unknown_after"), &deadline)...)
+
+	var reasons basetypes.MapValue
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("unresolved_reasons"), &reasons)...)
+
+	if diagnostics.HasError() {
+		return
+	}
+
+	unresolvedAfterDeadline(deadline, reasons, now, diagnostics)
+}
+
+// unresolvedAfterDeadline adds an error if now is after the deadline and some result keys did not resolve, as given by
+// their unresolved reasons. An invalid deadline has already been reported by modify.
+func unresolvedAfterDeadline(deadline basetypes.StringValue, reasons basetypes.MapValue, now time.Time, diagnostics *diag.Diagnostics) {
+	if deadline.IsNull() || deadline.IsUnknown() || reasons.IsNull() || reasons.IsUnknown() || len(reasons.Elements()) == 0 {
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, deadline.ValueString())
+	if err != nil || !now.After(at) {
+		return
+	}
+
+	unresolved := sortedKeys(reasons)
+	for i, key := range unresolved {
+		unresolved[i] = fmt.Sprintf("%q", key)
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root("error_if_unknown_after"),
+		"Result keys are unresolved after the deadline",
+		fmt.Sprintf("%s did not resolve, which is no longer tolerated after %s.", strings.Join(unresolved, ", "), deadline.ValueString()),
+	)
+}
+
+// appliedAt returns the current time as an RFC 3339 timestamp.
+func appliedAt() basetypes.StringValue {
+	return basetypes.NewStringValue(time.Now().UTC().Format(time.RFC3339))
+}
+
+// resultHash returns the SHA-256 hash of result as a JSON string, as private state values must be JSON, or nil if
+// result is not wholly known.
+func resultHash(result basetypes.MapValue) []byte {
+	if result.IsUnknown() {
+		return nil
+	}
+
+	values := make(map[string]*string, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			return nil
+		}
+
+		values[key] = value.ValueStringPointer()
+	}
+
+	// Map keys are sorted when encoded, so equal results have equal hashes.
+	encoded, _ := json.Marshal(values)
+	if result.IsNull() {
+		encoded = []byte("null")
+	}
+
+	hash, _ := json.Marshal(fmt.Sprintf("%x", sha256.Sum256(encoded)))
+
+	return hash
+}
+
+// nextVersion returns 1 for the first result, the prior version if the result is unchanged or the next one if it
+// changed, which is unknown until the result is known.
+func nextVersion(hash, priorHash []byte, priorVersion basetypes.Int64Value) basetypes.Int64Value {
+	switch {
+	case hash == nil:
+		return basetypes.NewInt64Unknown()
+	case priorVersion.IsNull():
+		return basetypes.NewInt64Value(1)
+	case bytes.Equal(hash, priorHash):
+		return priorVersion
+	default:
+		return basetypes.NewInt64Value(priorVersion.ValueInt64() + 1)
+	}
+}
+
+// modify resolves the model and sets it on state. prior is the result in the prior state, if any. It works in phases:
+// the result is resolved, the outputs are derived from it and it is checked, and then it is withheld under
+// validate_only and kept consistent with what was planned at apply. Every computed attribute is set in one of these
+// phases, so that the rules of the later ones apply to all of them.
+func (r *MapResource) modify(ctx context.Context, model mapModel, prior basetypes.MapValue, diagnostics *diag.Diagnostics, state PlanOrState, errorOnUnresolved bool) {
+	// At apply, the model is read from the plan, so these are the values that were planned.
+	planned := model.Result
+	plannedKnown := model.KnownResult
+	plannedSafe := model.ResultSafe
+
+	m, ok := r.resolveModel(ctx, &model, prior, planned, diagnostics, errorOnUnresolved)
+	if !ok {
+		return
+	}
+
+	if !deriveOutputs(&model, m) {
+		return
+	}
+
+	if !checkResult(&model, m, diagnostics, errorOnUnresolved) {
+		return
+	}
+
+	// Everything has still been validated and resolved, but the result and everything derived from it is withheld.
+	if r.data.isValidateOnly() {
+		withholdResult(&model, errorOnUnresolved)
+	}
+
+	stabilizeOutputs(&model, prior, planned, plannedKnown, plannedSafe, errorOnUnresolved)
+
+	if model.PrintResult.ValueBool() {
+		r.data.printResult(ctx, model.Result)
+	}
+
+	diagnostics.Append(state.Set(ctx, model)...)
+}
+
+// mapResolution is what the resolve phase of modify works out from the model, for the phases after it.
+type mapResolution struct {
+	keys            []basetypes.StringValue
+	resultKeys      []basetypes.StringValue
+	values          []basetypes.StringValue
+	transforms      []func(string) string
+	transformsKnown bool
+	res             resolution
+
+	// resolved is the result before its values were transformed or replaced by the last good result.
+	resolved     basetypes.MapValue
+	keepLastGood bool
+
+	// When collecting errors, validation problems are gathered in validation rather than failing the plan or apply.
+	collectErrors bool
+	validation    *diag.Diagnostics
+}
+
+// resolveModel validates the inputs and resolves model.Result from them, returning false if modify should stop.
+func (r *MapResource) resolveModel(ctx context.Context, model *mapModel, prior, planned basetypes.MapValue, diagnostics *diag.Diagnostics, errorOnUnresolved bool) (mapResolution, bool) {
+	keys := make([]basetypes.StringValue, len(model.Keys.Elements()))
+	diagnostics.Append(model.Keys.ElementsAs(ctx, &keys, false)...)
+	if diagnostics.HasError() {
+		return mapResolution{}, false
+	}
+
+	var resultKeys []basetypes.StringValue
+
+	if !model.ConditionalResultKeys.IsNull() {
+		resultKeys = conditionalResultKeys(model.ConditionalResultKeys)
+	} else {
+		resultKeys = make([]basetypes.StringValue, len(model.ResultKeys.Elements()))
+		diagnostics.Append(model.ResultKeys.ElementsAs(ctx, &resultKeys, false)...)
+		if diagnostics.HasError() {
+			return mapResolution{}, false
+		}
+	}
+
+	values := make([]basetypes.StringValue, len(model.Values.Elements()))
+	diagnostics.Append(model.Values.ElementsAs(ctx, &values, false)...)
+	if diagnostics.HasError() {
+		return mapResolution{}, false
+	}
+
+	if !model.KeySource.IsNull() {
+		keys = keysFromSource(model.KeySource, len(values))
+	}
+
+	// Without result_keys every key is resolved, whereas an empty result_keys resolves none of them.
+	if model.ResultKeys.IsNull() && model.ConditionalResultKeys.IsNull() {
+		resultKeys = allKeys(keys)
+		if model.Keys.IsUnknown() {
+			resultKeys = []basetypes.StringValue{basetypes.NewStringUnknown()}
+		}
+	}
+
+	// When collecting errors, validation problems are gathered here rather than failing the plan or apply.
+	collectErrors := model.CollectErrors.ValueBool()
+	validation := diagnostics
+	if collectErrors {
+		validation = &diag.Diagnostics{}
+	}
+
+	// Result keys may be inherited or fall back rather than be in keys, so there can be more of them.
+	resultKeyCount := len(resultKeys)
+	if !model.InheritFrom.IsNull() || !model.FallbackSource.IsNull() || !model.DefaultValue.IsNull() {
+		resultKeyCount = 0
+	}
+
+	if !validateCounts(len(keys), resultKeyCount, len(values), validation) {
+		if !collectErrors {
+			return mapResolution{}, false
+		}
+
+		// Resolve the best-effort result from the keys and values that can be paired up.
+		if len(keys) > len(values) {
+			keys = keys[:len(values)]
+		} else {
+			values = values[:len(keys)]
+		}
+	}
+
+	if model.BlankIsNull.IsUnknown() || model.BlankIsNull.ValueBool() {
+		values = blankToNull(values, model.BlankIsNull.IsUnknown())
+	}
+
+	if !model.ValueFromKeyRegex.IsNull() {
+		replace := model.ValueReplace.ValueString()
+		if model.ValueReplace.IsNull() {
+			replace = "${0}"
+		}
+
+		if model.ValueFromKeyRegex.IsUnknown() || model.ValueReplace.IsUnknown() {
+			values = valuesFromKeys(keys, values, nil, replace)
+		} else if regex, err := compileRegex(model.ValueFromKeyRegex.ValueString()); err != nil {
+			validation.AddAttributeError(path.Root("value_from_key_regex"), "Invalid regular expression", err.Error())
+		} else {
+			values = valuesFromKeys(keys, values, regex, replace)
+		}
+	}
+
+	if !model.ErrorIfUnknownAfter.IsNull() && !model.ErrorIfUnknownAfter.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, model.ErrorIfUnknownAfter.ValueString()); err != nil {
+			validation.AddAttributeError(path.Root("error_if_unknown_after"), "Invalid timestamp", err.Error())
+		}
+	}
+
+	validateMatching("keys", keys, "key_validation_regex", model.KeyValidationRegex, model.KeyValidationMessage, "Invalid key", validation)
+	validateMatching("result_keys", resultKeys, "result_key_validation_regex", model.ResultKeyValidationRegex, basetypes.NewStringNull(), "Invalid result key", validation)
+	validateMatching("values", values, "value_validation_regex", model.ValueValidationRegex, model.ValueValidationMessage, "Invalid value", validation)
+	validateForbidden(keys, values, model.ForbiddenValues, validation)
+
+	values = defaultValues(keys, values, r.data.defaults())
+
+	transforms, transformsKnown := keyNormalizationTransforms(model.KeyNormalization, validation)
+	if len(transforms) > 0 {
+		keys = normalizeKeys(path.Root("keys"), keys, transforms, validation)
+		resultKeys = normalizeKeys(path.Root("result_keys"), resultKeys, transforms, validation)
+	}
+
+	if model.CoerceResultKeys.ValueBool() {
+		keys = coerceKeys(keys, resultKeys)
+	}
+
+	if !model.Aliases.IsNull() {
+		keys, values = aliasKeys(keys, values, resultKeys, model.Aliases, validation)
+	}
+
+	if validation.HasError() && !collectErrors {
+		return mapResolution{}, false
+	}
+
+	// Duplicate result keys only resolve once, so they are flagged at plan unless deduplication was asked for.
+	if duplicates := duplicateKeys(resultKeys); len(duplicates) > 0 && !errorOnUnresolved && !model.ResultKeysDedup.ValueBool() {
+		diagnostics.AddAttributeWarning(
+			path.Root("result_keys"),
+			"Result keys contain duplicates",
+			fmt.Sprintf("%s appear more than once, set result_keys_dedup to true if this is expected.", strings.Join(duplicates, ", ")),
+		)
+	}
+
+	// Overrides may be intended, so they are only flagged at plan when asked for.
+	if model.WarnOnShadow.ValueBool() && !errorOnUnresolved {
+		if shadowed := shadowedKeys(keys, values, model.InheritFrom); len(shadowed) > 0 {
+			diagnostics.AddAttributeWarning(
+				path.Root("keys"),
+				"Keys shadow earlier values",
+				strings.Join(shadowed, " "),
+			)
+		}
+	}
+
+	res := resolve(keys, resultKeys, values)
+	if !transformsKnown || model.CoerceResultKeys.IsUnknown() {
+		res.setUnknown()
+	}
+
+	res.inherit(model.InheritFrom)
+	res.fallback(model.FallbackSource, r.data.defaults())
+	res.defaultTo(model.DefaultValue)
+	res.overwrite(model.OverwriteKeys)
+
+	if rename, ok := replacements(model.ReplaceInKeys); !ok {
+		res.setUnknown()
+	} else if rename != nil {
+		if collisions := res.rename(rename); len(collisions) > 0 {
+			validation.AddAttributeError(
+				path.Root("replace_in_keys"),
+				"Replaced result keys collide",
+				strings.Join(collisions, " "),
+			)
+
+			if !collectErrors {
+				return mapResolution{}, false
+			}
+		}
+	}
+
+	if model.StripResultKeyPrefix.IsUnknown() {
+		res.setUnknown()
+	} else if !model.StripResultKeyPrefix.IsNull() {
+		prefix := model.StripResultKeyPrefix.ValueString()
+
+		if collisions := res.rename(func(key string) string { return strings.TrimPrefix(key, prefix) }); len(collisions) > 0 {
+			validation.AddAttributeError(
+				path.Root("strip_result_key_prefix"),
+				"Stripped result keys collide",
+				strings.Join(collisions, " "),
+			)
+
+			if !collectErrors {
+				return mapResolution{}, false
+			}
+		}
+	}
+
+	// Unresolved result keys may be tolerated, which is only known once the limit is.
+	if model.MaxUnknowns.IsUnknown() && res.missing() > 0 {
+		model.Result = basetypes.NewMapUnknown(types.StringType)
+	} else if collectErrors || !model.MaxUnknowns.IsNull() {
+		model.Result = res.partialResult()
+	} else {
+		model.Result = res.result()
+	}
+
+	resolved := model.Result
+
+	if model.TrimPrefix.IsUnknown() || model.TrimSuffix.IsUnknown() {
+		if !model.Result.IsNull() {
+			model.Result = basetypes.NewMapUnknown(types.StringType)
+		}
+	} else if !model.TrimPrefix.IsNull() || !model.TrimSuffix.IsNull() {
+		model.Result = transformValues(model.Result, func(value string) string {
+			return strings.TrimSuffix(strings.TrimPrefix(value, model.TrimPrefix.ValueString()), model.TrimSuffix.ValueString())
+		})
+	}
+
+	if replace, ok := replacements(model.ReplaceInValues); !ok {
+		if !model.Result.IsNull() {
+			model.Result = basetypes.NewMapUnknown(types.StringType)
+		}
+	} else if replace != nil {
+		model.Result = transformValues(model.Result, replace)
+	}
+
+	model.Result = applyTransforms(model.Result, model.Transforms)
+
+	if model.NormalizeDurations.IsUnknown() {
+		if !model.Result.IsNull() {
+			model.Result = basetypes.NewMapUnknown(types.StringType)
+		}
+	} else if model.ValueIsDuration.ValueBool() || model.NormalizeDurations.ValueBool() {
+		var ok bool
+		model.Result, ok = durationValues(model.Result, model.NormalizeDurations.ValueBool(), validation)
+
+		if !ok && !collectErrors {
+			return mapResolution{}, false
+		}
+	}
+
+	encoding := model.EncodeValues.ValueString()
+	if model.EncodeValues.IsNull() {
+		encoding = "none"
+	}
+
+	if model.EncodeValues.IsUnknown() || model.DecodeBeforeEncode.IsUnknown() {
+		if !model.Result.IsNull() {
+			model.Result = basetypes.NewMapUnknown(types.StringType)
+		}
+	} else if encoding != "none" || model.DecodeBeforeEncode.ValueBool() {
+		var ok bool
+		model.Result, ok = encodeValues(model.Result, encoding, model.DecodeBeforeEncode.ValueBool(), validation)
+
+		if !ok && !collectErrors {
+			return mapResolution{}, false
+		}
+	}
+
+	if model.StableResult.ValueBool() {
+		if errorOnUnresolved {
+			model.Result = stableResult(planned, model.Result)
+		} else if unknownCount(model.Result) > unknownCount(prior) {
+			model.Result = fillUnknownValues(model.Result, prior)
+		}
+	}
+
+	// The last good result is kept at plan as well as apply, so that they agree.
+	unresolved := res.decidable() && model.Result.IsNull()
+	keepLastGood := model.KeepLastGood.ValueBool() && unresolved && !prior.IsNull() && !prior.IsUnknown()
+	if keepLastGood {
+		model.Result = prior
+	}
+
+	return mapResolution{
+		keys:            keys,
+		resultKeys:      resultKeys,
+		values:          values,
+		transforms:      transforms,
+		transformsKnown: transformsKnown,
+		res:             res,
+		resolved:        resolved,
+		keepLastGood:    keepLastGood,
+		collectErrors:   collectErrors,
+		validation:      validation,
+	}, true
+}
+
+// deriveOutputs sets the computed attributes that are derived from the resolution and result, returning false if one
+// of them is invalid and errors are not being collected.
+func deriveOutputs(model *mapModel, m mapResolution) bool {
+	keys, resultKeys, values, res := m.keys, m.resultKeys, m.values, m.res
+	validation, collectErrors := m.validation, m.collectErrors
+
+	model.ResultKeysFound = foundResultKeys(keys, resultKeys)
+
+	model.ResultByGroup = resultByGroup(keys, values, model.ResultGroups, m.transforms, validation)
+
+	if model.ComputeComplement.IsUnknown() {
+		model.Complement = basetypes.NewMapUnknown(types.StringType)
+	} else if model.ComputeComplement.ValueBool() {
+		model.Complement = complement(keys, resultKeys, values)
+	} else {
+		model.Complement = basetypes.NewMapNull(types.StringType)
+	}
+
+	model.ResultKeysNotFound = notFoundResultKeys(keys, resultKeys)
+
+	if !m.transformsKnown || model.CoerceResultKeys.IsUnknown() {
+		if !model.Complement.IsNull() {
+			model.Complement = basetypes.NewMapUnknown(types.StringType)
+		}
+		if !model.ResultByGroup.IsNull() {
+			model.ResultByGroup = basetypes.NewMapUnknown(types.MapType{ElemType: types.StringType})
+		}
+		model.ResultKeysFound = basetypes.NewListUnknown(types.StringType)
+		model.ResultKeysNotFound = basetypes.NewListUnknown(types.StringType)
+	}
+
+	model.DefaultedKeys = res.defaultedKeys()
+	model.ResultSizeMatchesResultKeys = resultSizeMatches(m.resolved, res)
+	model.ResolvedFlags = res.resolvedFlags()
+	model.ResultCount = res.count()
+	model.UnresolvedReasons = res.unresolvedReasons()
+
+	if m.transformsKnown {
+		model.KeyIndex = keyIndex(keys, resultKeys)
+		model.KeyPositions = keyPositions(keys, resultKeys)
+	} else {
+		model.KeyIndex = basetypes.NewMapUnknown(types.StringType)
+		model.KeyPositions = basetypes.NewListUnknown(types.Int64Type)
+	}
+
+	if res.entriesUnknown {
+		model.ResultJSONPath = basetypes.NewStringUnknown()
+		model.ResultJSONSchema = basetypes.NewStringUnknown()
+	} else {
+		model.ResultJSONPath = basetypes.NewStringValue(resultJSONPath(res))
+		model.ResultJSONSchema = basetypes.NewStringValue(resultJSONSchema(res))
+	}
+
+	if model.ResultTemplate.IsNull() {
+		model.ResultRendered = basetypes.NewStringNull()
+	} else if model.ResultTemplate.IsUnknown() || res.entriesUnknown {
+		model.ResultRendered = basetypes.NewStringUnknown()
+	} else if rendered, ok := renderTemplate(model.ResultTemplate.ValueString(), res, model.Result, validation); ok {
+		model.ResultRendered = rendered
+	} else {
+		if !collectErrors {
+			return false
+		}
+
+		model.ResultRendered = basetypes.NewStringNull()
+	}
+
+	if model.ParseValuesAs.IsUnknown() || model.Result.IsUnknown() {
+		model.ParsedResult = basetypes.NewDynamicUnknown()
+	} else if model.ParseValuesAs.IsNull() || model.Result.IsNull() {
+		model.ParsedResult = basetypes.NewDynamicNull()
+	} else if parsed, ok := parseValues(model.Result, model.ParseValuesAs.ValueString(), validation); ok {
+		model.ParsedResult = basetypes.NewDynamicValue(parsed)
+	} else {
+		if !collectErrors {
+			return false
+		}
+
+		model.ParsedResult = basetypes.NewDynamicNull()
+	}
+
+	// result_keys_order and order_by conflict, and without either the pairs are sorted by key.
+	orderAttribute := model.OrderBy
+	if !model.ResultKeysOrder.IsNull() {
+		orderAttribute = model.ResultKeysOrder
+	}
+
+	order := orderAttribute.ValueString()
+	if orderAttribute.IsNull() {
+		order = "key"
+	}
+
+	// The order has already been validated, so this is only false while it is unknown.
+	orderedKeys, ok := orderResultKeys(res, keys, order)
+
+	// Ordering by value needs every value to be known.
+	if model.Result.IsUnknown() || orderAttribute.IsUnknown() || (order == "value" && !res.valuesKnown()) {
+		model.ResultPairs = basetypes.NewListUnknown(resultPairType)
+	} else if model.Result.IsNull() || !ok {
+		model.ResultPairs = basetypes.NewListNull(resultPairType)
+	} else {
+		model.ResultPairs = resultPairs(orderedKeys, model.Result)
+	}
+
+	// Unlike result_pairs, this is always in the order of result_keys.
+	if model.Result.IsUnknown() {
+		model.ResultAsList = basetypes.NewListUnknown(resultPairType)
+	} else if model.Result.IsNull() {
+		model.ResultAsList = basetypes.NewListNull(resultPairType)
+	} else {
+		inputKeys, _ := orderResultKeys(res, keys, "input")
+		model.ResultAsList = resultPairs(inputKeys, model.Result)
+	}
+
+	model.ResultCSV = csvResult(model.Result)
+	model.ResultEnvPairs = envPairs(model.Result)
+	model.ResultYAML = yamlResult(model.Result)
+
+	unknownIniValue := model.UnknownIniValue.ValueString()
+	if model.UnknownIniValue.IsNull() {
+		unknownIniValue = "__UNKNOWN__"
+	}
+
+	if model.UnknownIniValue.IsUnknown() {
+		model.ResultIni = basetypes.NewStringUnknown()
+	} else {
+		model.ResultIni = iniResult(model.Result, unknownIniValue)
+	}
+
+	if !model.ChunkSize.IsNull() && !model.ChunkSize.IsUnknown() && model.ChunkSize.ValueInt64() < 1 {
+		validation.AddAttributeError(path.Root("chunk_size"), "Chunk size must be at least 1", "")
+
+		if !collectErrors {
+			return false
+		}
+	}
+
+	if model.ChunkSize.IsUnknown() || model.ResultPairs.IsUnknown() {
+		model.ResultChunks = basetypes.NewListUnknown(types.ListType{ElemType: resultPairType})
+	} else if model.ChunkSize.IsNull() || model.ChunkSize.ValueInt64() < 1 || model.ResultPairs.IsNull() {
+		model.ResultChunks = basetypes.NewListNull(types.ListType{ElemType: resultPairType})
+	} else {
+		model.ResultChunks = chunkPairs(model.ResultPairs, int(model.ChunkSize.ValueInt64()))
+	}
+
+	model.NullSafeResult = nullSafeResult(model.Result)
+	model.ResultWithoutDefaults = resultWithoutDefaults(model.Result, res, model.DefaultValue)
+
+	unknownPlaceholder := model.UnknownPlaceholder
+	if unknownPlaceholder.IsNull() {
+		unknownPlaceholder = basetypes.NewStringValue("__UNKNOWN__")
+	}
+
+	model.KnownResult = knownResult(model.Result)
+	model.ResultSafe = safeResult(model.Result, unknownPlaceholder)
+	model.ResultGoMap = model.Result
+	model.ResultTags = model.Tags
+
+	return true
+}
+
+// checkResult validates the result, which is an error at apply but in some cases only a warning at plan, and lists the
+// errors when they are being collected. It returns false if modify should stop.
+func checkResult(model *mapModel, m mapResolution, diagnostics *diag.Diagnostics, errorOnUnresolved bool) bool {
+	res, validation, collectErrors := m.res, m.validation, m.collectErrors
+
+	// Whether every result key was found can only be decided once the keys and result keys are known, which is always
+	// the case at apply.
+	if m.keepLastGood {
+		diagnostics.AddWarning(
+			"Keeping the last good result",
+			"Some result_keys could not be resolved, so the result in the prior state is kept.",
+		)
+	} else if errorOnUnresolved || (collectErrors && res.decidable() && !model.MaxUnknowns.IsUnknown()) {
+		if !validateUnresolved(res, model.MaxUnknowns, validation) && !collectErrors {
+			return false
+		}
+	}
+
+	if !validateSize(model.Result, model.MinKeys, model.MaxKeys, validation) && !collectErrors {
+		return false
+	}
+
+	if model.PlanWarningOnEmptyResult.ValueBool() && !errorOnUnresolved && isEmptyResult(model.Result) {
+		diagnostics.AddAttributeWarning(
+			path.Root("result"),
+			"Result map is empty",
+			"None of the result_keys resolved to an entry, check that they overlap with keys.",
+		)
+	}
+
+	// The result may still be filled in by values that are unknown at plan, so this is only an error at apply.
+	if model.FailOnEmptyResult.ValueBool() && errorOnUnresolved && isEmptyResult(model.Result) {
+		validation.AddAttributeError(
+			path.Root("result"),
+			"Result map is empty",
+			"None of the result_keys resolved to an entry, check that they overlap with keys.",
+		)
+
+		if !collectErrors {
+			return false
+		}
+	}
+
+	if mismatches := resultMismatches(model.Result, model.ExpectedResult); len(mismatches) > 0 {
+		detail := fmt.Sprintf("%s differ from expected_result.", strings.Join(mismatches, ", "))
+
+		if errorOnUnresolved {
+			validation.AddAttributeError(path.Root("expected_result"), "Result does not match expected_result", detail)
+
+			if !collectErrors {
+				return false
+			}
+		} else {
+			diagnostics.AddAttributeWarning(path.Root("expected_result"), "Result does not match expected_result", detail)
+		}
+	}
+
+	if !collectErrors {
+		model.Errors = basetypes.NewListNull(types.StringType)
+	} else if !res.decidable() || model.MaxUnknowns.IsUnknown() {
+		model.Errors = basetypes.NewListUnknown(types.StringType)
+	} else {
+		model.Errors = errorsList(*validation)
+	}
+
+	return true
+}
+
+// withholdResult replaces the result and every other output computed from the resolution with null at apply, or
+// unknown at plan, for validate_only. Only id, errors, result_tags and the change tracking of the withheld result
+// (changed_keys, last_modified and version) are kept.
+func withholdResult(model *mapModel, errorOnUnresolved bool) {
+	stringMap := basetypes.NewMapUnknown(types.StringType)
+	pairList := basetypes.NewListUnknown(resultPairType)
+	chunkList := basetypes.NewListUnknown(types.ListType{ElemType: resultPairType})
+	dynamic := basetypes.NewDynamicUnknown()
+	text := basetypes.NewStringUnknown()
+	stringList := basetypes.NewListUnknown(types.StringType)
+	positionList := basetypes.NewListUnknown(types.Int64Type)
+	groupMap := basetypes.NewMapUnknown(types.MapType{ElemType: types.StringType})
+	flagMap := basetypes.NewMapUnknown(types.BoolType)
+	flag := basetypes.NewBoolUnknown()
+	count := basetypes.NewObjectUnknown(resultCountType.AttrTypes)
+	if errorOnUnresolved {
+		stringMap = basetypes.NewMapNull(types.StringType)
+		pairList = basetypes.NewListNull(resultPairType)
+		chunkList = basetypes.NewListNull(types.ListType{ElemType: resultPairType})
+		dynamic = basetypes.NewDynamicNull()
+		text = basetypes.NewStringNull()
+		stringList = basetypes.NewListNull(types.StringType)
+		positionList = basetypes.NewListNull(types.Int64Type)
+		groupMap = basetypes.NewMapNull(types.MapType{ElemType: types.StringType})
+		flagMap = basetypes.NewMapNull(types.BoolType)
+		flag = basetypes.NewBoolNull()
+		count = basetypes.NewObjectNull(resultCountType.AttrTypes)
+	}
+
+	model.Result = stringMap
+	model.Complement = stringMap
+	model.DefaultedKeys = stringList
+	model.KeyIndex = stringMap
+	model.KeyPositions = positionList
+	model.KnownResult = stringMap
+	model.NullSafeResult = stringMap
+	model.ParsedResult = dynamic
+	model.ResolvedFlags = flagMap
+	model.ResultAsList = pairList
+	model.ResultByGroup = groupMap
+	model.ResultCSV = text
+	model.ResultChunks = chunkList
+	model.ResultCount = count
+	model.ResultEnvPairs = stringList
+	model.ResultGoMap = stringMap
+	model.ResultIni = text
+	model.ResultJSONPath = text
+	model.ResultJSONSchema = text
+	model.ResultKeysFound = stringList
+	model.ResultKeysNotFound = stringList
+	model.ResultPairs = pairList
+	model.ResultRendered = text
+	model.ResultSafe = stringMap
+	model.ResultSizeMatchesResultKeys = flag
+	model.ResultWithoutDefaults = stringMap
+	model.ResultYAML = text
+	model.UnresolvedReasons = stringMap
+}
+
+// stabilizeOutputs keeps the outputs that were planned from unknown values consistent with the plan at apply, and sets
+// the keys that changed from prior.
+func stabilizeOutputs(model *mapModel, prior, planned, plannedKnown, plannedSafe basetypes.MapValue, errorOnUnresolved bool) {
+	// The placeholders and known entries that were planned are kept at apply, so that they agree. refreshOutputs
+	// replaces them with the applied values on the next refresh.
+	if errorOnUnresolved {
+		if !plannedKnown.IsNull() && !plannedKnown.IsUnknown() {
+			model.KnownResult = plannedKnown
+		}
+		model.ResultSafe = stableResult(plannedSafe, model.ResultSafe)
+	}
+
+	// At apply, values that were unknown at plan count as changed, as they did then.
+	if errorOnUnresolved {
+		model.ChangedKeys = changedKeys(prior, planned, model.Result)
+	} else {
+		model.ChangedKeys = changedKeys(prior, basetypes.NewMapNull(types.StringType), model.Result)
+	}
+}
+
+// isEmptyResult returns whether result is known to have no entries.
+func isEmptyResult(result basetypes.MapValue) bool {
+	return !result.IsUnknown() && !result.IsNull() && len(result.Elements()) == 0
+}
+
+// validateUnresolved checks that every result key was resolved, or when maxUnknowns is set that no more than that
+// many were not.
+func validateUnresolved(res resolution, maxUnknowns basetypes.Int64Value, diagnostics *diag.Diagnostics) bool {
+	if maxUnknowns.IsNull() {
+		if res.missing() > 0 {
+			diagnostics.AddError("Unable to resolve some result_keys, is it a subset of keys?", "")
+			return false
+		}
+
+		return true
+	}
+
+	if unresolved := res.missing(); int64(unresolved) > maxUnknowns.ValueInt64() {
+		diagnostics.AddAttributeError(
+			path.Root("max_unknowns"),
+			"Too many result_keys are unresolved",
+			fmt.Sprintf("%d result keys could not be resolved, more than the %d allowed.", unresolved, maxUnknowns.ValueInt64()),
+		)
+		return false
+	}
+
+	return true
+}
+
+// keyPositionLookup finds the position of each key in keys. As with resolve, the last occurrence of a key is used,
+// so a position is unknown if an unknown key comes after it.
+type keyPositionLookup struct {
+	positions   map[string]int
+	lastUnknown int
+}
+
+func newKeyPositionLookup(keys []basetypes.StringValue) keyPositionLookup {
+	p := keyPositionLookup{positions: make(map[string]int), lastUnknown: -1}
+
+	for i, key := range keys {
+		if key.IsUnknown() {
+			p.lastUnknown = i
+			continue
+		}
+
+		p.positions[key.ValueString()] = i
+	}
+
+	return p
+}
+
+// position returns the position of key in keys, which is null when it is definitely not in keys.
+func (p keyPositionLookup) position(key basetypes.StringValue) basetypes.Int64Value {
+	if key.IsUnknown() {
+		return basetypes.NewInt64Unknown()
+	}
+
+	position, ok := p.positions[key.ValueString()]
+	if ok && position > p.lastUnknown {
+		return basetypes.NewInt64Value(int64(position))
+	} else if p.lastUnknown >= 0 {
+		return basetypes.NewInt64Unknown()
+	}
+
+	return basetypes.NewInt64Null()
+}
+
+// keyIndex maps each result key to its position in keys as a string, leaving out result keys that are not in keys.
+func keyIndex(keys, resultKeys []basetypes.StringValue) basetypes.MapValue {
+	p := newKeyPositionLookup(keys)
+	index := make(map[string]attr.Value)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		}
+
+		switch position := p.position(resultKey); {
+		case position.IsUnknown():
+			index[resultKey.ValueString()] = basetypes.NewStringUnknown()
+		case !position.IsNull():
+			index[resultKey.ValueString()] = basetypes.NewStringValue(strconv.FormatInt(position.ValueInt64(), 10))
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, index)
+}
+
+// keyPositions lists the position in keys of each result key in order, with null for those that are not in keys.
+func keyPositions(keys, resultKeys []basetypes.StringValue) basetypes.ListValue {
+	p := newKeyPositionLookup(keys)
+	positions := make([]attr.Value, len(resultKeys))
+
+	for i, resultKey := range resultKeys {
+		positions[i] = p.position(resultKey)
+	}
+
+	return basetypes.NewListValueMust(types.Int64Type, positions)
+}
+
+// stableResult returns result with each of its entries taken from preferred instead when that has a known value for
+// it.
+func stableResult(preferred, result basetypes.MapValue) basetypes.MapValue {
+	if result.IsUnknown() || result.IsNull() || preferred.IsUnknown() || preferred.IsNull() {
+		return result
+	}
+
+	stable := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, value := range result.Elements() {
+		stable[key] = value
+
+		if preferredValue, ok := preferred.Elements()[key]; ok && !preferredValue.IsUnknown() {
+			stable[key] = preferredValue
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, stable)
+}
+
+// fillUnknownValues returns result with each of its unknown values taken from fallback instead when that has a known
+// value for the same key. Entries of fallback whose keys are not in result are left out.
+func fillUnknownValues(result, fallback basetypes.MapValue) basetypes.MapValue {
+	if result.IsUnknown() || result.IsNull() || fallback.IsUnknown() || fallback.IsNull() {
+		return result
+	}
+
+	filled := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, value := range result.Elements() {
+		filled[key] = value
+
+		if fallbackValue, ok := fallback.Elements()[key]; ok && value.IsUnknown() && !fallbackValue.IsUnknown() {
+			filled[key] = fallbackValue
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, filled)
+}
+
+// unknownCount returns the number of unknown values in m.
+func unknownCount(m basetypes.MapValue) int {
+	count := 0
+
+	for _, value := range m.Elements() {
+		if value.IsUnknown() {
+			count += 1
+		}
+	}
+
+	return count
+}
+
+// changedKeys returns the sorted keys whose entry differs between prior and result, counting an entry as changed if
+// its value is unknown in either result or planned.
+func changedKeys(prior, planned, result basetypes.MapValue) basetypes.ListValue {
+	if result.IsUnknown() {
+		return basetypes.NewListUnknown(types.StringType)
+	}
+
+	if result.IsNull() {
+		return basetypes.NewListNull(types.StringType)
+	}
+
+	var changed []string
+
+	for key, value := range result.Elements() {
+		priorValue, ok := prior.Elements()[key]
+
+		if plannedValue, planned := planned.Elements()[key]; planned && plannedValue.IsUnknown() {
+			changed = append(changed, key)
+		} else if !ok || value.IsUnknown() || !value.Equal(priorValue) {
+			changed = append(changed, key)
+		}
+	}
+
+	for key := range prior.Elements() {
+		if _, ok := result.Elements()[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+
+	sort.Strings(changed)
+
+	elements := make([]attr.Value, len(changed))
+	for i, key := range changed {
+		elements[i] = basetypes.NewStringValue(key)
+	}
+
+	return basetypes.NewListValueMust(types.StringType, elements)
+}
+
+// resultMismatches returns the sorted keys whose entries differ between result and expected, including keys that are
+// only in one of them. Entries whose value is unknown on either side are skipped, as is everything while either map
+// is unknown or null.
+func resultMismatches(result, expected basetypes.MapValue) []string {
+	if result.IsUnknown() || result.IsNull() || expected.IsUnknown() || expected.IsNull() {
+		return nil
+	}
+
+	var mismatches []string
+
+	for key, value := range result.Elements() {
+		expectedValue, ok := expected.Elements()[key]
+		if !ok {
+			mismatches = append(mismatches, key)
+		} else if !value.IsUnknown() && !expectedValue.IsUnknown() && !value.Equal(expectedValue) {
+			mismatches = append(mismatches, key)
+		}
+	}
+
+	for key := range expected.Elements() {
+		if _, ok := result.Elements()[key]; !ok {
+			mismatches = append(mismatches, key)
+		}
+	}
+
+	sort.Strings(mismatches)
+
+	return mismatches
+}
+
+// validateSize checks that the number of entries in result is within the known bounds, which can only be done once
+// result is known.
+func validateSize(result basetypes.MapValue, minKeys, maxKeys basetypes.Int64Value, diagnostics *diag.Diagnostics) bool {
+	if result.IsNull() || result.IsUnknown() {
+		return true
+	}
+
+	size := int64(len(result.Elements()))
+
+	if !minKeys.IsNull() && !minKeys.IsUnknown() && size < minKeys.ValueInt64() {
+		diagnostics.AddAttributeError(
+			path.Root("min_keys"),
+			"Result has too few keys",
+			fmt.Sprintf("Result has %d keys, but at least %d are required.", size, minKeys.ValueInt64()),
+		)
+
+		return false
+	}
+
+	if !maxKeys.IsNull() && !maxKeys.IsUnknown() && size > maxKeys.ValueInt64() {
+		diagnostics.AddAttributeError(
+			path.Root("max_keys"),
+			"Result has too many keys",
+			fmt.Sprintf("Result has %d keys, but at most %d are allowed.", size, maxKeys.ValueInt64()),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// errorsList describes each error diagnostic as a string, prefixed by the attribute it relates to if any.
+func errorsList(diagnostics diag.Diagnostics) basetypes.ListValue {
+	messages := make([]attr.Value, 0, diagnostics.ErrorsCount())
+
+	for _, diagnostic := range diagnostics.Errors() {
+		message := diagnostic.Summary()
+
+		if withPath, ok := diagnostic.(diag.DiagnosticWithPath); ok {
+			message = withPath.Path().String() + ": " + message
+		}
+
+		messages = append(messages, basetypes.NewStringValue(message))
+	}
+
+	return basetypes.NewListValueMust(types.StringType, messages)
+}
+
+// validateCounts checks that keys and values are the same length and that there are no more result keys than keys,
+// returning false after adding attribute errors if not.
+func validateCounts(keyCount, resultKeyCount, valueCount int, diagnostics *diag.Diagnostics) bool {
+	if keyCount > valueCount {
+		diagnostics.AddAttributeError(path.Root("keys"), "Key count is higher than the number of values", "")
+		diagnostics.AddAttributeError(path.Root("values"), "Value count is lower than the number of keys", "")
+		return false
+	} else if keyCount < valueCount {
+		diagnostics.AddAttributeError(path.Root("keys"), "Key count is lower than the number of values", "")
+		diagnostics.AddAttributeError(path.Root("values"), "Value count is higher than the number of keys", "")
+		return false
+	} else if resultKeyCount > keyCount {
+		diagnostics.AddAttributeError(path.Root("result_keys"), "Result key count is higher than the number of keys", "")
+		return false
+	}
+
+	return true
+}
+
+type jsonSchema struct {
+	Type       string                `json:"type"`
+	Properties map[string]jsonSchema `json:"properties,omitempty"`
+	Required   []string              `json:"required,omitempty"`
+}
+
+// resultSizeMatches reports whether the result has an entry for each of the result keys.
+func resultSizeMatches(result basetypes.MapValue, res resolution) basetypes.BoolValue {
+	if result.IsUnknown() {
+		return basetypes.NewBoolUnknown()
+	}
+
+	return basetypes.NewBoolValue(!result.IsNull() && len(result.Elements()) == len(res.entries))
+}
+
+// resultJSONSchema describes the result as a JSON Schema object with a required string property for each result key,
+// which only depends on the result keys.
+func resultJSONSchema(res resolution) string {
+	objectSchema := jsonSchema{
+		Type:       "object",
+		Properties: make(map[string]jsonSchema, len(res.entries)),
+		Required:   make([]string, 0, len(res.entries)),
+	}
+
+	for _, entry := range res.entries {
+		objectSchema.Properties[entry.key] = jsonSchema{Type: "string"}
+		objectSchema.Required = append(objectSchema.Required, entry.key)
+	}
+
+	sort.Strings(objectSchema.Required)
+
+	// Marshalling strings and maps of strings cannot fail.
+	encoded, _ := json.Marshal(objectSchema)
+
+	return string(encoded)
+}
+
+// jsonPathIdentifier matches the keys that can be written in dot notation in a JSONPath expression.
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// resultJSONPath lists a JSONPath expression for each result key separated by semicolons, using bracket notation for
+// keys that are not plain identifiers.
+func resultJSONPath(res resolution) string {
+	paths := make([]string, len(res.entries))
+
+	for i, entry := range res.entries {
+		if jsonPathIdentifier.MatchString(entry.key) {
+			paths[i] = "$." + entry.key
+		} else {
+			paths[i] = "$['" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(entry.key) + "']"
+		}
+	}
+
+	return strings.Join(paths, ";")
+}
+
+var resultPairType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"key":   types.StringType,
+		"value": types.StringType,
+	},
+}
+
+// orderResultKeys returns the distinct result keys in the given order, or false if the order is not supported. The
+// sorts are stable, so result keys that compare equal stay in the order they were given.
+func orderResultKeys(res resolution, keys []basetypes.StringValue, order string) ([]string, bool) {
+	entries := make([]resolutionEntry, len(res.entries))
+	copy(entries, res.entries)
+
+	switch order {
+	case "input":
+	case "keys_input":
+		// Result keys that are not in keys, such as inherited ones, go last.
+		positions := make(map[string]int)
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !keys[i].IsNull() && !keys[i].IsUnknown() {
+				positions[keys[i].ValueString()] = i
+			}
+		}
+
+		position := func(key string) int {
+			if i, ok := positions[key]; ok {
+				return i
+			}
+			return len(keys)
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return position(entries[i].source) < position(entries[j].source)
+		})
+	case "key", "lexicographic":
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].key < entries[j].key
+		})
+	case "reverse":
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	case "value":
+		// Null values go first.
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].value.IsNull() || entries[j].value.IsNull() {
+				return entries[i].value.IsNull() && !entries[j].value.IsNull()
+			}
+			return entries[i].value.ValueString() < entries[j].value.ValueString()
+		})
+	default:
+		return nil, false
+	}
+
+	ordered := make([]string, len(entries))
+	for i, entry := range entries {
+		ordered[i] = entry.key
+	}
+
+	return ordered, true
+}
+
+// resultPairs lists the key and value of each entry in the result, in the order of the given keys.
+func resultPairs(keys []string, result basetypes.MapValue) basetypes.ListValue {
+	pairs := make([]attr.Value, 0, len(keys))
+
+	for _, key := range keys {
+		value, ok := result.Elements()[key]
+		if !ok {
+			continue
+		}
+
+		pairs = append(pairs, basetypes.NewObjectValueMust(resultPairType.AttrTypes, map[string]attr.Value{
+			"key":   basetypes.NewStringValue(key),
+			"value": value,
+		}))
+	}
+
+	return basetypes.NewListValueMust(resultPairType, pairs)
+}
+
+// sortedKeys returns the keys of a map in lexicographic order.
+func sortedKeys(m basetypes.MapValue) []string {
+	keys := make([]string, 0, len(m.Elements()))
+	for key := range m.Elements() {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// csvResult writes result as CSV with a key,value header and a row per entry in key order, where null values are
+// empty. It is unknown if any value is.
+func csvResult(result basetypes.MapValue) basetypes.StringValue {
+	if result.IsUnknown() {
+		return basetypes.NewStringUnknown()
+	}
+
+	if result.IsNull() {
+		return basetypes.NewStringNull()
+	}
+
+	keys := sortedKeys(result)
+
+	records := [][]string{{"key", "value"}}
+
+	for _, key := range keys {
+		value := result.Elements()[key].(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			return basetypes.NewStringUnknown()
+		}
+
+		records = append(records, []string{key, value.ValueString()})
+	}
+
+	var encoded strings.Builder
+
+	// Writing to a strings.Builder cannot fail.
+	_ = csv.NewWriter(&encoded).WriteAll(records)
+
+	return basetypes.NewStringValue(encoded.String())
+}
+
+// yamlResult writes result as a YAML mapping in key order, where null values are null. It is unknown if any value is.
+func yamlResult(result basetypes.MapValue) basetypes.StringValue {
+	if result.IsUnknown() {
+		return basetypes.NewStringUnknown()
+	}
+
+	if result.IsNull() {
+		return basetypes.NewStringNull()
+	}
+
+	mapping := make(map[string]*string, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			return basetypes.NewStringUnknown()
+		}
+
+		mapping[key] = value.ValueStringPointer()
+	}
+
+	// Mappings of strings always marshal, with their keys sorted.
+	encoded, _ := yaml.Marshal(mapping)
+
+	return basetypes.NewStringValue(string(encoded))
+}
+
+// envPairs lists each entry of result as KEY=value in key order, where an unknown value makes its entry unknown and a
+// null value is empty.
+func envPairs(result basetypes.MapValue) basetypes.ListValue {
+	if result.IsUnknown() {
+		return basetypes.NewListUnknown(types.StringType)
+	}
+
+	if result.IsNull() {
+		return basetypes.NewListNull(types.StringType)
+	}
+
+	keys := sortedKeys(result)
+
+	pairs := make([]attr.Value, len(keys))
+
+	for i, key := range keys {
+		value := result.Elements()[key].(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			pairs[i] = basetypes.NewStringUnknown()
+		} else {
+			pairs[i] = basetypes.NewStringValue(key + "=" + value.ValueString())
+		}
+	}
+
+	return basetypes.NewListValueMust(types.StringType, pairs)
+}
+
+// iniKeyEscaper and iniValueEscaper escape what would otherwise end an INI key or line early.
+var (
+	iniKeyEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "=", `\=`)
+	iniValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+)
+
+// iniResult writes each entry of result as a key = value line in key order, with unknownValue in place of unknown
+// values. Keys and values are escaped, so that each entry is exactly one line.
+func iniResult(result basetypes.MapValue, unknownValue string) basetypes.StringValue {
+	if result.IsUnknown() {
+		return basetypes.NewStringUnknown()
+	}
+
+	if result.IsNull() {
+		return basetypes.NewStringNull()
+	}
+
+	keys := sortedKeys(result)
+
+	var ini strings.Builder
+
+	for _, key := range keys {
+		value := result.Elements()[key].(basetypes.StringValue)
+
+		if value.IsUnknown() {
+			fmt.Fprintf(&ini, "%s = %s\n", iniKeyEscaper.Replace(key), iniValueEscaper.Replace(unknownValue))
+		} else {
+			fmt.Fprintf(&ini, "%s = %s\n", iniKeyEscaper.Replace(key), iniValueEscaper.Replace(value.ValueString()))
+		}
+	}
+
+	return basetypes.NewStringValue(ini.String())
+}
+
+// chunkPairs splits the pairs into lists of the given size, with any remainder in the last list.
+func chunkPairs(pairs basetypes.ListValue, size int) basetypes.ListValue {
+	elements := pairs.Elements()
+	chunks := make([]attr.Value, 0, (len(elements)+size-1)/size)
+
+	for start := 0; start < len(elements); start += size {
+		end := start + size
+		if end > len(elements) {
+			end = len(elements)
+		}
+
+		chunks = append(chunks, basetypes.NewListValueMust(resultPairType, elements[start:end]))
+	}
+
+	return basetypes.NewListValueMust(types.ListType{ElemType: resultPairType}, chunks)
+}
+
+// duplicateKeys returns each known key that appears more than once, quoted, in the order they first repeat.
+func duplicateKeys(keys []basetypes.StringValue) []string {
+	var duplicates []string
+	counts := make(map[string]int)
+
+	for _, key := range keys {
+		if key.IsNull() || key.IsUnknown() {
+			continue
+		}
+
+		counts[key.ValueString()] += 1
+		if counts[key.ValueString()] == 2 {
+			duplicates = append(duplicates, strconv.Quote(key.ValueString()))
+		}
+	}
+
+	return duplicates
+}
+
+// shadowedKeys describes each known key whose value replaces a different known value given before it, either earlier in
+// keys or in base, which comes before the keys.
+func shadowedKeys(keys, values []basetypes.StringValue, base basetypes.MapValue) []string {
+	earlier := make(map[string]basetypes.StringValue)
+	sources := make(map[string]string)
+
+	if !base.IsNull() && !base.IsUnknown() {
+		for key, value := range base.Elements() {
+			earlier[key] = value.(basetypes.StringValue)
+			sources[key] = "inherit_from"
+		}
+	}
+
+	var shadowed []string
+
+	for i, key := range keys {
+		if key.IsNull() || key.IsUnknown() {
+			continue
+		}
+
+		value := values[i]
+
+		if previous, ok := earlier[key.ValueString()]; ok && !previous.IsUnknown() && !value.IsUnknown() && !previous.Equal(value) {
+			shadowed = append(shadowed, fmt.Sprintf("%q is %s in %s but %s later in keys.", key.ValueString(), previous, sources[key.ValueString()], value))
+		}
+
+		earlier[key.ValueString()] = value
+		sources[key.ValueString()] = "keys"
+	}
+
+	return shadowed
+}
+
+// blankToNull replaces each known value that is empty or only whitespace with null, or with unknown if whether to do
+// so is itself unknown.
+func blankToNull(values []basetypes.StringValue, unknown bool) []basetypes.StringValue {
+	converted := make([]basetypes.StringValue, len(values))
+
+	for i, value := range values {
+		switch {
+		case value.IsNull() || value.IsUnknown() || strings.TrimSpace(value.ValueString()) != "":
+			converted[i] = value
+		case unknown:
+			converted[i] = basetypes.NewStringUnknown()
+		default:
+			converted[i] = basetypes.NewStringNull()
+		}
+	}
+
+	return converted
+}
+
+// maxRegexLength bounds the length of regular expressions from configuration.
+const maxRegexLength = 1024
+
+// compileRegex compiles a regular expression from configuration. Go regular expressions run in time linear in the
+// size of the input, so patterns that backtrack catastrophically in other engines are safe, but the pattern itself is
+// bounded so that the work per match stays small.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxRegexLength {
+		return nil, fmt.Errorf("pattern is %d characters long, more than the maximum of %d", len(pattern), maxRegexLength)
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// validateMatching adds an error with message, or defaultMessage if it is not set, on attribute for the known elements
+// that do not match pattern, unless pattern is not set or not known yet.
+func validateMatching(attribute string, elements []basetypes.StringValue, patternAttribute string, pattern, message basetypes.StringValue, defaultMessage string, diagnostics *diag.Diagnostics) {
+	if pattern.IsNull() || pattern.IsUnknown() {
+		return
+	}
+
+	summary := message.ValueString()
+	if message.IsNull() || message.IsUnknown() {
+		summary = defaultMessage
+	}
+
+	regex, err := compileRegex(pattern.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root(patternAttribute), "Invalid regular expression", err.Error())
+		return
+	}
+
+	if unmatched := unmatchedElements(elements, regex); len(unmatched) > 0 {
+		diagnostics.AddAttributeError(
+			path.Root(attribute),
+			summary,
+			fmt.Sprintf("%s do not match %q.", strings.Join(unmatched, ", "), pattern.ValueString()),
+		)
+	}
+}
+
+// validateForbidden adds an attribute error naming the key for each known value that is one of the forbidden values.
+func validateForbidden(keys, values []basetypes.StringValue, forbidden basetypes.SetValue, diagnostics *diag.Diagnostics) {
+	if forbidden.IsNull() || forbidden.IsUnknown() {
+		return
+	}
+
+	forbiddenValues := make(map[string]bool)
+	for _, element := range forbidden.Elements() {
+		if value, ok := element.(basetypes.StringValue); ok && !value.IsNull() && !value.IsUnknown() {
+			forbiddenValues[value.ValueString()] = true
+		}
+	}
+
+	for i, value := range values {
+		if value.IsNull() || value.IsUnknown() || !forbiddenValues[value.ValueString()] {
+			continue
+		}
+
+		diagnostics.AddAttributeError(
+			path.Root("values").AtListIndex(i),
+			"Value is forbidden",
+			fmt.Sprintf("The value of key %s is %q, which is one of forbidden_values.", keys[i], value.ValueString()),
+		)
+	}
+}
+
+// unmatchedElements returns the known elements that do not match regex, quoted, in the order they were given.
+func unmatchedElements(elements []basetypes.StringValue, regex *regexp.Regexp) []string {
+	var unmatched []string
+
+	for _, element := range elements {
+		if element.IsNull() || element.IsUnknown() || regex.MatchString(element.ValueString()) {
+			continue
+		}
+
+		unmatched = append(unmatched, fmt.Sprintf("%q", element.ValueString()))
+	}
+
+	return unmatched
+}
+
+// valuesFromKeys fills in each null value by replacing the matches of regex in its key, expanding $1 or ${name} in
+// replace as with regexp.Regexp.ReplaceAllString. Values whose keys do not match stay null, and a nil regex makes
+// them unknown.
+func valuesFromKeys(keys, values []basetypes.StringValue, regex *regexp.Regexp, replace string) []basetypes.StringValue {
+	derived := make([]basetypes.StringValue, len(values))
+
+	for i, value := range values {
+		switch {
+		case !value.IsNull():
+			derived[i] = value
+		case regex == nil || keys[i].IsUnknown():
+			derived[i] = basetypes.NewStringUnknown()
+		case regex.MatchString(keys[i].ValueString()):
+			derived[i] = basetypes.NewStringValue(regex.ReplaceAllString(keys[i].ValueString(), replace))
+		default:
+			derived[i] = value
+		}
+	}
+
+	return derived
+}
+
+var valueEncoders = map[string]func([]byte) string{
+	"base64":    base64.StdEncoding.EncodeToString,
+	"base64url": base64.URLEncoding.EncodeToString,
+	"hex":       hex.EncodeToString,
+	"none":      func(b []byte) string { return string(b) },
+}
+
+// defaultValues replaces each null value with the default for its key, if there is one. A default that cannot be
+// looked up yet is unknown.
+func defaultValues(keys, values []basetypes.StringValue, defaults basetypes.MapValue) []basetypes.StringValue {
+	if defaults.IsNull() || (!defaults.IsUnknown() && len(defaults.Elements()) == 0) {
+		return values
+	}
+
+	filled := make([]basetypes.StringValue, len(values))
+
+	for i, value := range values {
+		if !value.IsNull() {
+			filled[i] = value
+			continue
+		}
+
+		if defaults.IsUnknown() || keys[i].IsUnknown() {
+			filled[i] = basetypes.NewStringUnknown()
+			continue
+		}
+
+		filled[i] = value
+		if defaultValue, ok := defaults.Elements()[keys[i].ValueString()].(basetypes.StringValue); ok {
+			filled[i] = defaultValue
+		}
+	}
+
+	return filled
+}
+
+// transformValues applies transform to each known value of result, leaving null and unknown values as they are.
+func transformValues(result basetypes.MapValue, transform func(string) string) basetypes.MapValue {
+	if result.IsNull() || result.IsUnknown() {
+		return result
+	}
+
+	transformed := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		value := element.(basetypes.StringValue)
+
+		if value.IsNull() || value.IsUnknown() {
+			transformed[key] = value
+		} else {
+			transformed[key] = basetypes.NewStringValue(transform(value.ValueString()))
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, transformed)
+}
+
+// valueTransforms are the transforms supported by transforms.
+var valueTransforms = map[string]func(string) string{
+	"base64encode": func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	},
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"upper": strings.ToUpper,
+}
+
+// applyTransforms applies the named transform of each key in transforms to its known value in result. A value whose
+// transform is unknown becomes unknown.
+func applyTransforms(result, transforms basetypes.MapValue) basetypes.MapValue {
+	if result.IsNull() || result.IsUnknown() || transforms.IsNull() {
+		return result
+	}
+
+	if transforms.IsUnknown() {
+		return basetypes.NewMapUnknown(types.StringType)
+	}
+
+	transformed := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		value := element.(basetypes.StringValue)
+		name, ok := transforms.Elements()[key].(basetypes.StringValue)
+
+		switch {
+		case !ok || name.IsNull() || value.IsNull() || value.IsUnknown():
+			transformed[key] = value
+		case name.IsUnknown():
+			transformed[key] = basetypes.NewStringUnknown()
+		default:
+			transform, ok := valueTransforms[name.ValueString()]
+			if !ok {
+				transformed[key] = value
+				continue
+			}
+
+			transformed[key] = basetypes.NewStringValue(transform(value.ValueString()))
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, transformed)
+}
+
+// resultWithoutDefaults leaves the entries of result that were defaulted out, or is null when there is no default.
+func resultWithoutDefaults(result basetypes.MapValue, res resolution, defaultValue basetypes.StringValue) basetypes.MapValue {
+	if defaultValue.IsNull() {
+		return basetypes.NewMapNull(types.StringType)
+	}
+
+	if result.IsNull() || result.IsUnknown() {
+		return result
+	}
+
+	defaulted := make(map[string]bool)
+	for _, entry := range res.entries {
+		defaulted[entry.key] = entry.defaulted
+	}
+
+	resolved := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, value := range result.Elements() {
+		if !defaulted[key] {
+			resolved[key] = value
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, resolved)
+}
+
+// knownResult leaves out the unknown values of result, or is empty if result is null or unknown.
+func knownResult(result basetypes.MapValue) basetypes.MapValue {
+	known := make(map[string]attr.Value)
+
+	for key, element := range result.Elements() {
+		if !element.IsUnknown() {
+			known[key] = element
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, known)
+}
+
+// safeResult replaces the unknown values of result with the placeholder, which is unknown while the placeholder is and
+// there are unknown values to replace.
+func safeResult(result basetypes.MapValue, placeholder basetypes.StringValue) basetypes.MapValue {
+	if result.IsNull() || result.IsUnknown() {
+		return result
+	}
+
+	safe := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		if !element.IsUnknown() {
+			safe[key] = element
+		} else if placeholder.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		} else {
+			safe[key] = placeholder
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, safe)
+}
+
+// nullSafeResult replaces the null values of result with empty strings.
+func nullSafeResult(result basetypes.MapValue) basetypes.MapValue {
+	if result.IsNull() || result.IsUnknown() {
+		return result
+	}
+
+	safe := make(map[string]attr.Value, len(result.Elements()))
+
+	for key, element := range result.Elements() {
+		if element.IsNull() {
+			safe[key] = basetypes.NewStringValue("")
+		} else {
+			safe[key] = element
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, safe)
+}
+
+// encodeValues encodes each known value of result, first base64 decoding it if decode is set. Values that cannot be
+// decoded are reported with their key and left out, returning false.
+func encodeValues(result basetypes.MapValue, encoding string, decode bool, diagnostics *diag.Diagnostics) (basetypes.MapValue, bool) {
+	if result.IsNull() || result.IsUnknown() {
+		return result, true
+	}
+
+	// Sorted so that errors are reported in a stable order.
+	keys := sortedKeys(result)
+
+	encoded := make(map[string]attr.Value, len(keys))
+	ok := true
+
+	for _, key := range keys {
+		value, isString := result.Elements()[key].(basetypes.StringValue)
+		if !isString || value.IsNull() || value.IsUnknown() {
+			encoded[key] = result.Elements()[key]
+			continue
+		}
+
+		bytes := []byte(value.ValueString())
+
+		if decode {
+			var err error
+			if bytes, err = base64.StdEncoding.DecodeString(value.ValueString()); err != nil {
+				diagnostics.AddAttributeError(
+					path.Root("values"),
+					fmt.Sprintf("Value of key %q is not valid base64", key),
+					err.Error(),
+				)
+				ok = false
+				continue
+			}
+		}
+
+		encoded[key] = basetypes.NewStringValue(valueEncoders[encoding](bytes))
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, encoded), ok
+}
+
+// renderTemplate renders text with the values of result, or returns unknown when a value it references is unknown.
+// Null values render as empty strings. Returns false after adding errors if text is not a valid template, references a
+// key that is not a result key or fails to render.
+func renderTemplate(text string, res resolution, result basetypes.MapValue, diagnostics *diag.Diagnostics) (basetypes.StringValue, bool) {
+	tmpl, err := template.New("result_template").Option("missingkey=error").Parse(text)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("result_template"), "Invalid template", err.Error())
+		return basetypes.NewStringNull(), false
+	}
+
+	resultKeys := make(map[string]bool, len(res.entries))
+	for _, entry := range res.entries {
+		resultKeys[entry.key] = true
+	}
+
+	var references []string
+	if tmpl.Tree != nil {
+		references = templateReferences(tmpl.Tree.Root, nil)
+	}
+
+	ok := true
+
+	for _, reference := range references {
+		if !resultKeys[reference] {
+			diagnostics.AddAttributeError(
+				path.Root("result_template"),
+				"Template references a key that is not in result_keys",
+				fmt.Sprintf("%q is not one of the result_keys.", reference),
+			)
+			ok = false
+		}
+	}
+
+	if !ok {
+		return basetypes.NewStringNull(), false
+	}
+
+	if result.IsUnknown() {
+		return basetypes.NewStringUnknown(), true
+	}
+
+	if result.IsNull() {
+		return basetypes.NewStringNull(), true
+	}
+
+	for _, reference := range references {
+		if value, found := result.Elements()[reference]; found && value.IsUnknown() {
+			return basetypes.NewStringUnknown(), true
+		}
+	}
+
+	data := make(map[string]string, len(result.Elements()))
+	for key, element := range result.Elements() {
+		if value, isString := element.(basetypes.StringValue); isString && !value.IsUnknown() {
+			data[key] = value.ValueString()
+		}
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		diagnostics.AddAttributeError(path.Root("result_template"), "Unable to render template", err.Error())
+		return basetypes.NewStringNull(), false
+	}
+
+	return basetypes.NewStringValue(rendered.String()), true
+}
+
+// templateReferences appends the keys referenced as {{.key}} or {{$.key}} anywhere in node to references.
+func templateReferences(node parse.Node, references []string) []string {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return references
+		}
+		for _, child := range node.Nodes {
+			references = templateReferences(child, references)
+		}
+	case *parse.ActionNode:
+		references = templateReferences(node.Pipe, references)
+	case *parse.PipeNode:
+		if node == nil {
+			return references
+		}
+		for _, command := range node.Cmds {
+			references = templateReferences(command, references)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			references = templateReferences(arg, references)
+		}
+	case *parse.ChainNode:
+		references = templateReferences(node.Node, references)
+	case *parse.FieldNode:
+		references = append(references, node.Ident[0])
+	case *parse.VariableNode:
+		if len(node.Ident) > 1 && node.Ident[0] == "$" {
+			references = append(references, node.Ident[1])
+		}
+	case *parse.IfNode:
+		references = templateReferences(&node.BranchNode, references)
+	case *parse.RangeNode:
+		references = templateReferences(&node.BranchNode, references)
+	case *parse.WithNode:
+		references = templateReferences(&node.BranchNode, references)
+	case *parse.BranchNode:
+		references = templateReferences(node.Pipe, references)
+		references = templateReferences(node.List, references)
+		references = templateReferences(node.ElseList, references)
+	case *parse.TemplateNode:
+		references = templateReferences(node.Pipe, references)
+	}
+
+	return references
+}
+
+// durationValues checks that each known value of result parses as a duration, reporting those that do not with their
+// key and returning false. When normalizing, values are rewritten in the canonical form of their duration.
+func durationValues(result basetypes.MapValue, normalize bool, diagnostics *diag.Diagnostics) (basetypes.MapValue, bool) {
+	if result.IsNull() || result.IsUnknown() {
+		return result, true
+	}
+
+	// Sorted so that errors are reported in a stable order.
+	keys := sortedKeys(result)
+
+	durations := make(map[string]attr.Value, len(keys))
+	ok := true
+
+	for _, key := range keys {
+		value := result.Elements()[key].(basetypes.StringValue)
+		durations[key] = value
+
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("values"),
+				fmt.Sprintf("Value of key %q is not a valid duration", key),
+				err.Error(),
+			)
+			ok = false
+			continue
+		}
+
+		if normalize {
+			durations[key] = basetypes.NewStringValue(duration.String())
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, durations), ok
+}
+
+// parseValues parses each known value of result as a number or bool, returning a map of that type. Values that
+// cannot be parsed are reported with their key, returning false.
+func parseValues(result basetypes.MapValue, as string, diagnostics *diag.Diagnostics) (basetypes.MapValue, bool) {
+	var elementType attr.Type = types.NumberType
+	if as == "bool" {
+		elementType = types.BoolType
+	}
+
+	// Sorted so that errors are reported in a stable order.
+	keys := sortedKeys(result)
+
+	parsed := make(map[string]attr.Value, len(keys))
+	ok := true
+
+	for _, key := range keys {
+		value, isString := result.Elements()[key].(basetypes.StringValue)
+		if !isString {
+			continue
+		}
+
+		parsedValue, parsedOk := parseValue(value, as)
+		if !parsedOk {
+			diagnostics.AddAttributeError(
+				path.Root("values"),
+				fmt.Sprintf("Value of key %q is not a valid %s", key, as),
+				fmt.Sprintf("%q cannot be parsed as a %s.", value.ValueString(), as),
+			)
+			ok = false
+			continue
+		}
+
+		parsed[key] = parsedValue
+	}
+
+	if !ok {
+		return basetypes.NewMapNull(elementType), false
+	}
+
+	return basetypes.NewMapValueMust(elementType, parsed), true
+}
+
+// parseValue parses a string value as a number or bool, following Terraform's own conversions. Unknown and null
+// values stay unknown and null.
+func parseValue(value basetypes.StringValue, as string) (attr.Value, bool) {
+	if as == "bool" {
+		switch {
+		case value.IsUnknown():
+			return basetypes.NewBoolUnknown(), true
+		case value.IsNull():
+			return basetypes.NewBoolNull(), true
+		case value.ValueString() == "true":
+			return basetypes.NewBoolValue(true), true
+		case value.ValueString() == "false":
+			return basetypes.NewBoolValue(false), true
+		default:
+			return nil, false
+		}
+	}
+
+	switch {
+	case value.IsUnknown():
+		return basetypes.NewNumberUnknown(), true
+	case value.IsNull():
+		return basetypes.NewNumberNull(), true
+	}
+
+	number, _, err := big.ParseFloat(value.ValueString(), 10, 512, big.ToNearestEven)
+	if err != nil || number.IsInf() {
+		return nil, false
+	}
+
+	return basetypes.NewNumberValue(number), true
+}
+
+var conditionalResultKeyType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"include": types.BoolType,
+		"key":     types.StringType,
+	},
+}
+
+// keysFromSource returns the values of source in the order of its sorted keys. While source is unknown, there is an
+// unknown key for each of the values, as that is how many keys it must turn out to have.
+func keysFromSource(source basetypes.MapValue, valueCount int) []basetypes.StringValue {
+	if source.IsUnknown() {
+		keys := make([]basetypes.StringValue, valueCount)
+		for i := range keys {
+			keys[i] = basetypes.NewStringUnknown()
+		}
+		return keys
+	}
+
+	sourceKeys := sortedKeys(source)
+	keys := make([]basetypes.StringValue, len(sourceKeys))
+
+	for i, sourceKey := range sourceKeys {
+		keys[i] = source.Elements()[sourceKey].(basetypes.StringValue)
+	}
+
+	return keys
+}
+
+// foundResultKeys lists each result key that is one of the known keys once, which an unknown key cannot change.
+func foundResultKeys(keys, resultKeys []basetypes.StringValue) basetypes.ListValue {
+	known := make(map[string]bool)
+
+	for _, key := range keys {
+		if !key.IsNull() && !key.IsUnknown() {
+			known[key.ValueString()] = true
+		}
+	}
+
+	found := []attr.Value{}
+	seen := make(map[string]bool)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsUnknown() {
+			return basetypes.NewListUnknown(types.StringType)
+		}
+
+		if resultKey.IsNull() || !known[resultKey.ValueString()] || seen[resultKey.ValueString()] {
+			continue
+		}
+
+		seen[resultKey.ValueString()] = true
+		found = append(found, resultKey)
+	}
+
+	return basetypes.NewListValueMust(types.StringType, found)
+}
+
+// notFoundResultKeys lists each result key that is not one of the keys once. If a key is unknown, any of them may turn
+// out to be found instead.
+func notFoundResultKeys(keys, resultKeys []basetypes.StringValue) basetypes.ListValue {
+	res := resolve(keys, resultKeys, make([]basetypes.StringValue, len(keys)))
+	if res.entriesUnknown {
+		return basetypes.NewListUnknown(types.StringType)
+	}
+
+	notFound := []attr.Value{}
+
+	for _, entry := range res.entries {
+		if entry.found {
+			continue
+		}
+
+		if res.keysUnknown > 0 {
+			return basetypes.NewListUnknown(types.StringType)
+		}
+
+		notFound = append(notFound, basetypes.NewStringValue(entry.key))
+	}
+
+	return basetypes.NewListValueMust(types.StringType, notFound)
+}
+
+// importedMap parses a JSON object of strings, returning its sorted keys and their values along with the object as a
+// map.
+func importedMap(id string) (basetypes.ListValue, basetypes.ListValue, basetypes.MapValue, error) {
+	var object map[string]*string
+
+	if err := json.Unmarshal([]byte(id), &object); err != nil {
+		return basetypes.ListValue{}, basetypes.ListValue{}, basetypes.MapValue{}, err
+	}
+
+	if object == nil {
+		return basetypes.ListValue{}, basetypes.ListValue{}, basetypes.MapValue{}, fmt.Errorf("null is not an object")
+	}
+
+	sorted := make([]string, 0, len(object))
+	for key := range object {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	keys := make([]attr.Value, 0, len(object))
+	values := make([]attr.Value, 0, len(object))
+	result := make(map[string]attr.Value, len(object))
+
+	for _, key := range sorted {
+		value := basetypes.NewStringPointerValue(object[key])
+
+		keys = append(keys, basetypes.NewStringValue(key))
+		values = append(values, value)
+		result[key] = value
+	}
+
+	return basetypes.NewListValueMust(types.StringType, keys),
+		basetypes.NewListValueMust(types.StringType, values),
+		basetypes.NewMapValueMust(types.StringType, result),
+		nil
+}
+
+// complement maps each key that is not one of the result keys to its value, where a later key takes precedence as it
+// does when resolving. It is unknown while any key or result key is, as that may change which keys are in it.
+func complement(keys, resultKeys, values []basetypes.StringValue) basetypes.MapValue {
+	excluded := make(map[string]bool)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		}
+
+		excluded[resultKey.ValueString()] = true
+	}
+
+	entries := make(map[string]attr.Value)
+
+	for i, key := range keys {
+		if key.IsUnknown() {
+			return basetypes.NewMapUnknown(types.StringType)
+		}
+
+		if !excluded[key.ValueString()] {
+			entries[key.ValueString()] = values[i]
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, entries)
+}
+
+// resultByGroup resolves the result keys of each group into its own map, normalizing them as the keys were.
+func resultByGroup(keys, values []basetypes.StringValue, groups basetypes.MapValue, transforms []func(string) string, diagnostics *diag.Diagnostics) basetypes.MapValue {
+	mapType := types.MapType{ElemType: types.StringType}
+
+	if groups.IsNull() {
+		return basetypes.NewMapNull(mapType)
+	}
+
+	if groups.IsUnknown() {
+		return basetypes.NewMapUnknown(mapType)
+	}
+
+	results := make(map[string]attr.Value, len(groups.Elements()))
+
+	for name, element := range groups.Elements() {
+		group := element.(basetypes.ListValue)
+
+		if group.IsNull() {
+			results[name] = basetypes.NewMapNull(types.StringType)
+			continue
+		}
+
+		if group.IsUnknown() {
+			results[name] = basetypes.NewMapUnknown(types.StringType)
+			continue
+		}
+
+		groupKeys := make([]basetypes.StringValue, len(group.Elements()))
+		for i, groupKey := range group.Elements() {
+			groupKeys[i] = groupKey.(basetypes.StringValue)
+		}
+
+		if len(transforms) > 0 {
+			groupKeys = normalizeKeys(path.Root("result_groups").AtMapKey(name), groupKeys, transforms, diagnostics)
+		}
+
+		results[name] = resolveMap(keys, groupKeys, values)
+	}
+
+	return basetypes.NewMapValueMust(mapType, results)
+}
+
+// allKeys is used as the result keys when none are given, listing each key once in the order they first appear.
+func allKeys(keys []basetypes.StringValue) []basetypes.StringValue {
+	var resultKeys []basetypes.StringValue
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		if key.IsUnknown() {
+			resultKeys = append(resultKeys, key)
+			continue
+		}
+
+		if key.IsNull() || seen[key.ValueString()] {
+			continue
+		}
+
+		seen[key.ValueString()] = true
+		resultKeys = append(resultKeys, key)
+	}
+
+	return resultKeys
+}
+
+// conditionalResultKeys returns the keys whose include is not false. An unknown include makes its key unknown, as
+// whether it is in the result cannot be known yet.
+func conditionalResultKeys(conditionalKeys basetypes.ListValue) []basetypes.StringValue {
+	if conditionalKeys.IsUnknown() {
+		return []basetypes.StringValue{basetypes.NewStringUnknown()}
+	}
+
+	var resultKeys []basetypes.StringValue
+
+	for _, element := range conditionalKeys.Elements() {
+		conditionalKey, ok := element.(basetypes.ObjectValue)
+		if !ok || conditionalKey.IsNull() {
+			continue
+		}
+
+		if conditionalKey.IsUnknown() {
+			resultKeys = append(resultKeys, basetypes.NewStringUnknown())
+			continue
+		}
+
+		include := conditionalKey.Attributes()["include"].(basetypes.BoolValue)
+		key := conditionalKey.Attributes()["key"].(basetypes.StringValue)
+
+		if include.IsUnknown() {
+			resultKeys = append(resultKeys, basetypes.NewStringUnknown())
+		} else if include.IsNull() || include.ValueBool() {
+			resultKeys = append(resultKeys, key)
+		}
+	}
+
+	return resultKeys
+}
+
+var replacementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"from": types.StringType,
+		"to":   types.StringType,
+	},
+}
+
+// replacements returns a transform applying each from and to replacement in order, which is nil when there are none
+// and false when the replacements are not known yet.
+func replacements(rules basetypes.ListValue) (func(string) string, bool) {
+	if rules.IsUnknown() {
+		return nil, false
+	}
+
+	var froms, tos []string
+
+	for _, element := range rules.Elements() {
+		rule, ok := element.(basetypes.ObjectValue)
+		if !ok || rule.IsNull() {
+			continue
+		}
+
+		if rule.IsUnknown() {
+			return nil, false
+		}
+
+		from := rule.Attributes()["from"].(basetypes.StringValue)
+		to := rule.Attributes()["to"].(basetypes.StringValue)
+
+		if from.IsUnknown() || to.IsUnknown() {
+			return nil, false
+		}
+
+		if from.ValueString() == "" {
+			continue
+		}
+
+		froms = append(froms, from.ValueString())
+		tos = append(tos, to.ValueString())
+	}
+
+	if len(froms) == 0 {
+		return nil, true
+	}
+
+	return func(value string) string {
+		for i := range froms {
+			value = strings.ReplaceAll(value, froms[i], tos[i])
+		}
+
+		return value
+	}, true
+}
+
+// keyNormalizations are the transforms supported by key_normalization.
+var keyNormalizations = map[string]func(string) string{
+	"lower": strings.ToLower,
+	"nfc":   norm.NFC.String,
+	"trim":  strings.TrimSpace,
+}
+
+// keyNormalizationTransforms looks up each of the named key normalizations, returning false if they are not known yet.
+func keyNormalizationTransforms(names basetypes.ListValue, diagnostics *diag.Diagnostics) ([]func(string) string, bool) {
+	if names.IsUnknown() {
+		return nil, false
+	}
+
+	var transforms []func(string) string
+
+	for i, element := range names.Elements() {
+		name, ok := element.(basetypes.StringValue)
+		if !ok || name.IsNull() {
+			continue
+		}
+
+		if name.IsUnknown() {
+			return nil, false
+		}
+
+		transform, ok := keyNormalizations[name.ValueString()]
+		if !ok {
+			diagnostics.AddAttributeError(
+				path.Root("key_normalization").AtListIndex(i),
+				"Unsupported key normalization",
+				fmt.Sprintf("%q is not one of \"trim\", \"lower\" or \"nfc\".", name.ValueString()),
+			)
+			continue
+		}
+
+		transforms = append(transforms, transform)
+	}
+
+	return transforms, true
+}
+
+// normalizeKeys applies the transforms in order to each known key, adding an attribute error for any two different keys
+// that end up the same.
+func normalizeKeys(attribute path.Path, keys []basetypes.StringValue, transforms []func(string) string, diagnostics *diag.Diagnostics) []basetypes.StringValue {
+	normalized := make([]basetypes.StringValue, len(keys))
+	originals := make(map[string]string)
+
+	for i, key := range keys {
+		if key.IsNull() || key.IsUnknown() {
+			normalized[i] = key
+			continue
+		}
+
+		value := key.ValueString()
+		for _, transform := range transforms {
+			value = transform(value)
+		}
+
+		if original, ok := originals[value]; ok && original != key.ValueString() {
+			diagnostics.AddAttributeError(
+				attribute.AtListIndex(i),
+				"Key collides with another key after normalization",
+				fmt.Sprintf("%q and %q both normalize to %q.", original, key.ValueString(), value),
+			)
+		} else {
+			originals[value] = key.ValueString()
+		}
+
+		normalized[i] = basetypes.NewStringValue(value)
+	}
+
+	return normalized
+}
+
+// canonicalNumber returns the canonical form of a key that is a number, such as "1" for "1.0", and false otherwise.
+func canonicalNumber(key string) (string, bool) {
+	number, ok := new(big.Float).SetPrec(512).SetString(key)
+	if !ok || number.IsInf() {
+		return "", false
+	}
+
+	return number.Text('f', -1), true
+}
+
+// aliasKeys adds an entry for each result key that is an alias after every entry of its canonical key, so that it
+// resolves to the same value. An unknown alias could be any result key, so it adds an unknown key at the end instead.
+func aliasKeys(keys, values, resultKeys []basetypes.StringValue, aliases basetypes.MapValue, diagnostics *diag.Diagnostics) ([]basetypes.StringValue, []basetypes.StringValue) {
+	if aliases.IsUnknown() {
+		return append(keys, basetypes.NewStringUnknown()), append(values, basetypes.NewStringUnknown())
+	}
+
+	targets := make(map[string]string, len(aliases.Elements()))
+	unknown := false
+
+	for alias, target := range aliases.Elements() {
+		target := target.(basetypes.StringValue)
+
+		if target.IsUnknown() {
+			unknown = true
+		} else if !target.IsNull() {
+			targets[alias] = target.ValueString()
+		}
+	}
+
+	canonical := make(map[string][]string)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsNull() || resultKey.IsUnknown() {
+			continue
+		}
+
+		key, err := canonicalKey(resultKey.ValueString(), targets)
+		if err != nil {
+			diagnostics.AddAttributeError(path.Root("aliases"), "Cyclic aliases", err.Error())
+			continue
+		}
+
+		if key != resultKey.ValueString() {
+			canonical[key] = append(canonical[key], resultKey.ValueString())
+		}
+	}
+
+	aliasedKeys := make([]basetypes.StringValue, 0, len(keys))
+	aliasedValues := make([]basetypes.StringValue, 0, len(values))
+
+	for i, key := range keys {
+		aliasedKeys = append(aliasedKeys, key)
+		aliasedValues = append(aliasedValues, values[i])
+
+		if key.IsUnknown() || key.IsNull() {
+			continue
+		}
+
+		for _, alias := range canonical[key.ValueString()] {
+			aliasedKeys = append(aliasedKeys, basetypes.NewStringValue(alias))
+			aliasedValues = append(aliasedValues, values[i])
+		}
+	}
+
+	if unknown {
+		aliasedKeys = append(aliasedKeys, basetypes.NewStringUnknown())
+		aliasedValues = append(aliasedValues, basetypes.NewStringUnknown())
+	}
+
+	return aliasedKeys, aliasedValues
+}
+
+// canonicalKey follows key through targets until it reaches a key that is not an alias, returning an error that
+// shows the cycle if it comes back to an alias it has already followed.
+func canonicalKey(key string, targets map[string]string) (string, error) {
+	chain := []string{key}
+	seen := map[string]bool{key: true}
+
+	for {
+		target, ok := targets[key]
+		if !ok {
+			return key, nil
+		}
+
+		chain = append(chain, target)
+
+		if seen[target] {
+			return "", fmt.Errorf("aliases form a cycle: %s", strings.Join(chain, " -> "))
+		}
+
+		seen[target] = true
+		key = target
+	}
+}
+
+// coerceKeys replaces each key that is a number with the first result key that is the same number, so that they match
+// when resolved.
+func coerceKeys(keys, resultKeys []basetypes.StringValue) []basetypes.StringValue {
+	numbers := make(map[string]string)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsNull() || resultKey.IsUnknown() {
+			continue
+		}
+
+		if canonical, ok := canonicalNumber(resultKey.ValueString()); ok {
+			if _, seen := numbers[canonical]; !seen {
+				numbers[canonical] = resultKey.ValueString()
+			}
+		}
+	}
+
+	coerced := make([]basetypes.StringValue, len(keys))
+
+	for i, key := range keys {
+		coerced[i] = key
+
+		if key.IsNull() || key.IsUnknown() {
+			continue
+		}
+
+		if canonical, ok := canonicalNumber(key.ValueString()); ok {
+			if resultKey, ok := numbers[canonical]; ok {
+				coerced[i] = basetypes.NewStringValue(resultKey)
+			}
+		}
+	}
+
+	return coerced
+}
+
+type mapModel struct {
+	Aliases                     types.Map     `tfsdk:"aliases"`
+	BlankIsNull                 types.Bool    `tfsdk:"blank_is_null"`
+	ChangedKeys                 types.List    `tfsdk:"changed_keys"`
+	ChunkSize                   types.Int64   `tfsdk:"chunk_size"`
+	CoerceResultKeys            types.Bool    `tfsdk:"coerce_result_keys"`
+	CollectErrors               types.Bool    `tfsdk:"collect_errors"`
+	Complement                  types.Map     `tfsdk:"complement"`
+	ComputeComplement           types.Bool    `tfsdk:"compute_complement"`
+	ConditionalResultKeys       types.List    `tfsdk:"conditional_result_keys"`
+	DecodeBeforeEncode          types.Bool    `tfsdk:"decode_before_encode"`
+	DefaultValue                types.String  `tfsdk:"default_value"`
+	DefaultedKeys               types.List    `tfsdk:"defaulted_keys"`
+	Description                 types.String  `tfsdk:"description"`
+	EncodeValues                types.String  `tfsdk:"encode_values"`
+	ErrorIfUnknownAfter         types.String  `tfsdk:"error_if_unknown_after"`
+	Errors                      types.List    `tfsdk:"errors"`
+	ExpectedResult              types.Map     `tfsdk:"expected_result"`
+	FailOnEmptyResult           types.Bool    `tfsdk:"fail_on_empty_result"`
+	FallbackSource              types.Map     `tfsdk:"fallback_source"`
+	ForbiddenValues             types.Set     `tfsdk:"forbidden_values"`
+	ID                          types.String  `tfsdk:"id"`
+	InheritFrom                 types.Map     `tfsdk:"inherit_from"`
+	KeepLastGood                types.Bool    `tfsdk:"keep_last_good"`
+	KeyIndex                    types.Map     `tfsdk:"key_index"`
+	KeyNormalization            types.List    `tfsdk:"key_normalization"`
+	KeyPositions                types.List    `tfsdk:"key_positions"`
+	KeySource                   types.Map     `tfsdk:"key_source"`
+	KeyValidationMessage        types.String  `tfsdk:"key_validation_message"`
+	KeyValidationRegex          types.String  `tfsdk:"key_validation_regex"`
+	Keys                        types.List    `tfsdk:"keys"`
+	KnownResult                 types.Map     `tfsdk:"known_result"`
+	LastModified                types.String  `tfsdk:"last_modified"`
+	MaxKeys                     types.Int64   `tfsdk:"max_keys"`
+	MaxUnknowns                 types.Int64   `tfsdk:"max_unknowns"`
+	MinKeys                     types.Int64   `tfsdk:"min_keys"`
+	NormalizeDurations          types.Bool    `tfsdk:"normalize_durations"`
+	NullSafeResult              types.Map     `tfsdk:"null_safe_result"`
+	OrderBy                     types.String  `tfsdk:"order_by"`
+	OverwriteKeys               types.Map     `tfsdk:"overwrite_keys"`
+	ParseValuesAs               types.String  `tfsdk:"parse_values_as"`
+	ParsedResult                types.Dynamic `tfsdk:"parsed_result"`
+	PlanWarningOnEmptyResult    types.Bool    `tfsdk:"plan_warning_on_empty_result"`
+	PrintResult                 types.Bool    `tfsdk:"print_result"`
+	ReplaceInKeys               types.List    `tfsdk:"replace_in_keys"`
+	ReplaceInValues             types.List    `tfsdk:"replace_in_values"`
+	ResolvedFlags               types.Map     `tfsdk:"resolved_flags"`
+	Result                      types.Map     `tfsdk:"result"`
+	ResultAsList                types.List    `tfsdk:"result_as_list"`
+	ResultByGroup               types.Map     `tfsdk:"result_by_group"`
+	ResultChunks                types.List    `tfsdk:"result_chunks"`
+	ResultCount                 types.Object  `tfsdk:"result_count"`
+	ResultCSV                   types.String  `tfsdk:"result_csv"`
+	ResultEnvPairs              types.List    `tfsdk:"result_env_pairs"`
+	ResultGoMap                 types.Map     `tfsdk:"result_go_map"`
+	ResultGroups                types.Map     `tfsdk:"result_groups"`
+	ResultIni                   types.String  `tfsdk:"result_ini"`
+	ResultJSONPath              types.String  `tfsdk:"result_json_path"`
+	ResultJSONSchema            types.String  `tfsdk:"result_json_schema"`
+	ResultKeyValidationRegex    types.String  `tfsdk:"result_key_validation_regex"`
+	ResultKeys                  types.List    `tfsdk:"result_keys"`
+	ResultKeysDedup             types.Bool    `tfsdk:"result_keys_dedup"`
+	ResultKeysFound             types.List    `tfsdk:"result_keys_found"`
+	ResultKeysNotFound          types.List    `tfsdk:"result_keys_not_found"`
+	ResultKeysOrder             types.String  `tfsdk:"result_keys_order"`
+	ResultPairs                 types.List    `tfsdk:"result_pairs"`
+	ResultRendered              types.String  `tfsdk:"result_rendered"`
+	ResultSafe                  types.Map     `tfsdk:"result_safe"`
+	ResultSizeMatchesResultKeys types.Bool    `tfsdk:"result_size_matches_result_keys"`
+	ResultTags                  types.Map     `tfsdk:"result_tags"`
+	ResultTemplate              types.String  `tfsdk:"result_template"`
+	ResultWithoutDefaults       types.Map     `tfsdk:"result_without_defaults"`
+	ResultYAML                  types.String  `tfsdk:"result_yaml"`
+	StableResult                types.Bool    `tfsdk:"stable_result"`
+	StripResultKeyPrefix        types.String  `tfsdk:"strip_result_key_prefix"`
+	Tags                        types.Map     `tfsdk:"tags"`
+	Transforms                  types.Map     `tfsdk:"transforms"`
+	TrimPrefix                  types.String  `tfsdk:"trim_prefix"`
+	TrimSuffix                  types.String  `tfsdk:"trim_suffix"`
+	UnknownIniValue             types.String  `tfsdk:"unknown_ini_value"`
+	UnknownPlaceholder          types.String  `tfsdk:"unknown_placeholder"`
+	UnresolvedReasons           types.Map     `tfsdk:"unresolved_reasons"`
+	ValueFromKeyRegex           types.String  `tfsdk:"value_from_key_regex"`
+	ValueIsDuration             types.Bool    `tfsdk:"value_is_duration"`
+	ValueReplace                types.String  `tfsdk:"value_replace"`
+	ValueValidationMessage      types.String  `tfsdk:"value_validation_message"`
+	ValueValidationRegex        types.String  `tfsdk:"value_validation_regex"`
+	Values                      types.List    `tfsdk:"values"`
+	Version                     types.Int64   `tfsdk:"version"`
+	WarnOnShadow                types.Bool    `tfsdk:"warn_on_shadow"`
+}
+
+// resolution is the outcome of looking up each result key in the keys.
+type resolution struct {
+	// entries holds one entry per distinct result key, in the order they were given.
+	entries     []resolutionEntry
+	keysUnknown int
+	// entriesUnknown is set when the entries cannot be determined, such as when a result key is unknown, in which case
+	// entries is empty.
+	entriesUnknown bool
+}
+
+type resolutionEntry struct {
+	key string
+	// source is the result key as given, which differs from key once it has been renamed.
+	source string
+	// found is false when the result key is not one of the known keys, in which case value is not set.
+	found bool
+	// defaulted is set when the value is the default_value rather than found.
+	defaulted bool
+	value     basetypes.StringValue
+}
+
+func resolve(keys, resultKeys, values []basetypes.StringValue) resolution {
+	var res resolution
+	keyValueMapping := make(map[string]basetypes.StringValue)
+
+	for i := 0; i < len(keys); i++ {
+		if keys[i].IsUnknown() {
+			res.keysUnknown += 1
+			continue
+		}
+
+		// Of duplicate keys, the last known value wins, and an unknown value only when none is known.
+		if prior, ok := keyValueMapping[keys[i].ValueString()]; ok && values[i].IsUnknown() && !prior.IsUnknown() {
+			continue
+		}
+
+		keyValueMapping[keys[i].ValueString()] = values[i]
+	}
+
+	seen := make(map[string]bool)
+
+	for _, resultKey := range resultKeys {
+		if resultKey.IsUnknown() {
+			return resolution{keysUnknown: res.keysUnknown, entriesUnknown: true}
+		}
+
+		if seen[resultKey.ValueString()] {
+			continue
+		}
+
+		seen[resultKey.ValueString()] = true
+		value, found := keyValueMapping[resultKey.ValueString()]
+		res.entries = append(res.entries, resolutionEntry{key: resultKey.ValueString(), source: resultKey.ValueString(), found: found, value: value})
+	}
+
+	return res
+}
+
+// setUnknown marks the entries as undetermined, for when something they depend on is unknown.
+func (r *resolution) setUnknown() {
+	*r = resolution{keysUnknown: r.keysUnknown, entriesUnknown: true}
+}
+
+// inherit fills in the value of each result key that is not one of the known keys from base, as if base was given
+// before the keys.
+func (r *resolution) inherit(base basetypes.MapValue) {
+	if base.IsNull() {
+		return
+	}
+
+	if base.IsUnknown() {
+		if r.missing() > 0 {
+			r.setUnknown()
+		}
+		return
+	}
+
+	for i, entry := range r.entries {
+		if entry.found {
+			continue
+		}
+
+		value, ok := base.Elements()[entry.key].(basetypes.StringValue)
+		if !ok {
+			continue
+		}
+
+		r.entries[i].found = true
+		r.entries[i].value = value
+
+		// One of the unknown keys may turn out to be this key, taking precedence over the inherited value.
+		if r.keysUnknown > 0 {
+			r.entries[i].value = basetypes.NewStringUnknown()
+		}
+	}
+}
+
+// fallback resolves each result key that is still not found to its value in source, otherwise to its value in
+// defaults, otherwise to null, so that no result key is missing.
+func (r *resolution) fallback(source, defaults basetypes.MapValue) {
+	if source.IsNull() {
+		return
+	}
+
+	if source.IsUnknown() {
+		if r.missing() > 0 {
+			r.setUnknown()
+		}
+		return
+	}
+
+	for i, entry := range r.entries {
+		if entry.found {
+			continue
+		}
+
+		value, ok := source.Elements()[entry.key].(basetypes.StringValue)
+		if !ok {
+			value, ok = defaults.Elements()[entry.key].(basetypes.StringValue)
+		}
+
+		switch {
+		// One of the unknown keys may turn out to be this key, taking precedence over the fallback.
+		case r.keysUnknown > 0 || (!ok && defaults.IsUnknown()):
+			value = basetypes.NewStringUnknown()
+		case !ok:
+			value = basetypes.NewStringNull()
+		}
+
+		r.entries[i].found = true
+		r.entries[i].value = value
+	}
+}
+
+// defaultTo resolves each result key that is still not found to value, marking it as defaulted.
+func (r *resolution) defaultTo(value basetypes.StringValue) {
+	if value.IsNull() {
+		return
+	}
+
+	for i, entry := range r.entries {
+		if entry.found {
+			continue
+		}
+
+		r.entries[i].found = true
+		r.entries[i].defaulted = true
+		r.entries[i].value = value
+
+		// One of the unknown keys may turn out to be this key, taking precedence over the default.
+		if r.keysUnknown > 0 {
+			r.entries[i].value = basetypes.NewStringUnknown()
+		}
+	}
+}
+
+// overwrite replaces the value of each result key in overrides, regardless of whether it was found in the keys.
+func (r *resolution) overwrite(overrides basetypes.MapValue) {
+	if overrides.IsNull() {
+		return
+	}
+
+	if overrides.IsUnknown() {
+		r.setUnknown()
+		return
+	}
+
+	for i, entry := range r.entries {
+		override, ok := overrides.Elements()[entry.key].(basetypes.StringValue)
+		if !ok || override.IsNull() {
+			continue
+		}
+
+		r.entries[i].found = true
+		r.entries[i].defaulted = false
+		r.entries[i].value = override
+	}
+}
+
+// rename replaces the key of each entry with its transformed key, unless two entries would end up with the same key,
+// in which case the entries are left as they are and each collision is described.
+func (r *resolution) rename(transform func(string) string) []string {
+	renamed := make([]string, len(r.entries))
+	sources := make(map[string]string)
+	var collisions []string
+
+	for i, entry := range r.entries {
+		renamed[i] = transform(entry.key)
+
+		if source, ok := sources[renamed[i]]; ok {
+			collisions = append(collisions, fmt.Sprintf("%q and %q both become %q.", source, entry.key, renamed[i]))
+			continue
+		}
+
+		sources[renamed[i]] = entry.key
+	}
+
+	if len(collisions) > 0 {
+		return collisions
+	}
+
+	for i := range r.entries {
+		r.entries[i].key = renamed[i]
+	}
+
+	return nil
+}
+
+// unresolvedReasons maps each result key that did not resolve to a known value to why, which is unknown while the
+// entries are.
+func (r resolution) unresolvedReasons() basetypes.MapValue {
+	if r.entriesUnknown {
+		return basetypes.NewMapUnknown(types.StringType)
+	}
+
+	reasons := make(map[string]attr.Value)
+
+	for _, entry := range r.entries {
+		if status := r.status(entry); status != "resolved" {
+			reasons[entry.key] = basetypes.NewStringValue(status)
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.StringType, reasons)
+}
+
+// defaultedKeys lists the result keys that fell back to the default value. If a key is unknown, any of them may turn out
+// to be found instead.
+func (r resolution) defaultedKeys() basetypes.ListValue {
+	if r.entriesUnknown {
+		return basetypes.NewListUnknown(types.StringType)
+	}
+
+	defaulted := []attr.Value{}
+
+	for _, entry := range r.entries {
+		if !entry.defaulted {
+			continue
+		}
+
+		if r.keysUnknown > 0 {
+			return basetypes.NewListUnknown(types.StringType)
+		}
+
+		defaulted = append(defaulted, basetypes.NewStringValue(entry.key))
+	}
+
+	return basetypes.NewListValueMust(types.StringType, defaulted)
+}
+
+// resolvedFlags maps each result key to whether it resolved to a known value, which is unknown while its status can
+// still change.
+func (r resolution) resolvedFlags() basetypes.MapValue {
+	if r.entriesUnknown {
+		return basetypes.NewMapUnknown(types.BoolType)
+	}
+
+	flags := make(map[string]attr.Value)
+
+	for _, entry := range r.entries {
+		switch r.status(entry) {
+		case "resolved":
+			flags[entry.key] = basetypes.NewBoolValue(true)
+		case "missing":
+			flags[entry.key] = basetypes.NewBoolValue(false)
+		default:
+			flags[entry.key] = basetypes.NewBoolUnknown()
+		}
+	}
+
+	return basetypes.NewMapValueMust(types.BoolType, flags)
+}
+
+var resultCountType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"missing":  types.Int64Type,
+		"resolved": types.Int64Type,
+		"total":    types.Int64Type,
+		"unknown":  types.Int64Type,
+	},
+}
+
+// count summarizes the status of the entries, which is unknown while the entries are.
+func (r resolution) count() basetypes.ObjectValue {
+	if r.entriesUnknown {
+		return basetypes.NewObjectUnknown(resultCountType.AttrTypes)
+	}
+
+	counts := make(map[string]int64)
+
+	for _, entry := range r.entries {
+		switch r.status(entry) {
+		case "resolved":
+			counts["resolved"] += 1
+		case "missing":
+			counts["missing"] += 1
+		default:
+			counts["unknown"] += 1
+		}
+	}
+
+	return basetypes.NewObjectValueMust(resultCountType.AttrTypes, map[string]attr.Value{
+		"missing":  basetypes.NewInt64Value(counts["missing"]),
+		"resolved": basetypes.NewInt64Value(counts["resolved"]),
+		"total":    basetypes.NewInt64Value(int64(len(r.entries))),
+		"unknown":  basetypes.NewInt64Value(counts["unknown"]),
+	})
+}
+
+// status categorizes an entry as "resolved" when its value is known, otherwise as why it is not: "value_unknown",
+// "key_unknown" or "missing".
+func (r resolution) status(entry resolutionEntry) string {
+	switch {
+	case entry.found && entry.value.IsUnknown():
+		return "value_unknown"
+	case entry.found:
+		return "resolved"
+	case r.keysUnknown > 0:
+		return "key_unknown"
+	default:
+		return "missing"
+	}
+}
+
+// decidable reports whether it is known which result keys are in the keys.
+func (r resolution) decidable() bool {
+	return !r.entriesUnknown && r.keysUnknown == 0
+}
+
+// valuesKnown reports whether the value of every found entry is known.
+func (r resolution) valuesKnown() bool {
+	for _, entry := range r.entries {
+		if entry.found && entry.value.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// missing returns the number of result keys that are not one of the known keys.
+func (r resolution) missing() int {
+	count := 0
+
+	for _, entry := range r.entries {
+		if !entry.found {
+			count += 1
+		}
+	}
+
+	return count
+}
+
+// result returns the resolved map, which is unknown while any result key could still be one of the unknown keys and
+// null when some result keys are definitely missing.
+func (r resolution) result() basetypes.MapValue {
+	if r.entriesUnknown {
+		return basetypes.NewMapUnknown(basetypes.StringType{})
+	}
+
+	if missing := r.missing(); missing > 0 {
+		if missing <= r.keysUnknown {
+			return basetypes.NewMapUnknown(basetypes.StringType{})
+		} else {
 			return basetypes.NewMapNull(basetypes.StringType{})
 		}
 	}
 
+	return r.foundResult()
+}
+
+// partialResult returns the resolved map leaving out result keys that are missing from the keys, which is unknown
+// until that can be decided.
+func (r resolution) partialResult() basetypes.MapValue {
+	if r.entriesUnknown || (r.keysUnknown > 0 && r.missing() > 0) {
+		return basetypes.NewMapUnknown(basetypes.StringType{})
+	}
+
+	return r.foundResult()
+}
+
+func (r resolution) foundResult() basetypes.MapValue {
+	finalMapping := make(map[string]attr.Value)
+
+	for _, entry := range r.entries {
+		if !entry.found {
+			continue
+		}
+
+		finalMapping[entry.key] = entry.value
+	}
+
 	return basetypes.NewMapValueMust(types.StringType, finalMapping)
 }
+
+func resolveMap(keys, resultKeys, values []basetypes.StringValue) basetypes.MapValue {
+	return resolve(keys, resultKeys, values).result()
+}
@@ -4,17 +4,33 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"math/big"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"gopkg.in/yaml.v3"
 )
 
 func TestAccResourceMap(t *testing.T) {
@@ -52,6 +68,65 @@ func TestAccResourceMap(t *testing.T) {
 	})
 }
 
+func TestAccResourceMapPlanOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("resolver_map.test", plancheck.ResourceActionCreate),
+						plancheck.ExpectKnownValue(
+							"resolver_map.test",
+							tfjsonpath.New("result"),
+							knownvalue.MapExact(map[string]knownvalue.Check{
+								"a": knownvalue.StringExact("1"),
+								"c": knownvalue.StringExact("3"),
+							}),
+						),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapEmpty(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = []
+					result_keys = []
+					values      = []
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "keys.#", "0"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_keys.#", "0"),
+					resource.TestCheckResourceAttr("resolver_map.test", "values.#", "0"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceMapMoreKeysThanValues(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ErrorCheck: func(err error) error {
@@ -124,145 +199,7159 @@ func TestAccResourceMapTooManyResultKeys(t *testing.T) {
 	})
 }
 
-func TestInternalResolveMap(t *testing.T) {
-	var tests = []struct {
-		keys, resultKeys, values []basetypes.StringValue
-		expectedResult           basetypes.MapValue
-	}{
-		// basic cases
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("b"),
-				basetypes.NewStringValue("c"),
-			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("c"),
-			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringValue("2"),
-				basetypes.NewStringValue("3"),
-			},
-			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
-				"a": basetypes.NewStringValue("1"),
-				"c": basetypes.NewStringValue("3"),
-			}),
+func TestAccResourceMapCollectErrors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
 		},
-		// some keys unknown, but all result keys known
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringUnknown(),
-				basetypes.NewStringValue("c"),
-			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("c"),
-			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringValue("2"),
-				basetypes.NewStringValue("3"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b", "c"]
+					result_keys    = ["a", "d"]
+					values         = ["1", "2"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "errors.#", "3"),
+					resource.TestCheckResourceAttr("resolver_map.test", "errors.0", "keys: Key count is higher than the number of values"),
+					resource.TestCheckResourceAttr("resolver_map.test", "errors.1", "values: Value count is lower than the number of keys"),
+					resource.TestCheckResourceAttr("resolver_map.test", "errors.2", "Unable to resolve some result_keys, is it a subset of keys?"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+				),
 			},
-			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
-				"a": basetypes.NewStringValue("1"),
-				"c": basetypes.NewStringValue("3"),
-			}),
 		},
-		// some key value pairs unknown, but all result keys known
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringUnknown(),
-				basetypes.NewStringValue("c"),
+	})
+}
+
+func TestAccResourceMapOverwriteKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys           = ["a", "b", "c"]
+					overwrite_keys = { c = "30", d = "40" }
+					result_keys    = ["a", "c"]
+					values         = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.c", "30"),
+				),
 			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("c"),
+		},
+	})
+}
+
+func TestAccResourceMapKeyNormalization(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					key_normalization = ["trim", "lower"]
+					keys              = [" A", "b "]
+					result_keys       = ["a", "B"]
+					values            = ["1", "2"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.b", "2"),
+				),
 			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringUnknown(),
-				basetypes.NewStringValue("3"),
+		},
+	})
+}
+
+func TestAccResourceMapKeyNormalizationCollision(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					key_normalization = ["lower"]
+					keys              = ["a", "A"]
+					result_keys       = ["a"]
+					values            = ["1", "2"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Key collides with another key after normalization)`),
 			},
-			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
-				"a": basetypes.NewStringValue("1"),
-				"c": basetypes.NewStringValue("3"),
-			}),
 		},
-		// some key values unknown, but all result keys known
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("b"),
-				basetypes.NewStringValue("c"),
+	})
+}
+
+func TestAccResourceMapResultKeysOrder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["c", "a"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.value", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.key", "c"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.value", "3"),
+				),
 			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("c"),
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					result_keys       = ["c", "a"]
+					result_keys_order = "input"
+					values            = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "c"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.value", "3"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.value", "1"),
+				),
 			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringUnknown(),
-				basetypes.NewStringValue("3"),
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					result_keys       = ["c", "a"]
+					result_keys_order = "lexicographic"
+					values            = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.key", "c"),
+				),
 			},
-			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
-				"a": basetypes.NewStringValue("1"),
-				"c": basetypes.NewStringValue("3"),
-			}),
-		},
-		// some key values unknown, including some for result keys
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("b"),
-				basetypes.NewStringValue("c"),
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					order_by          = "keys_input"
+					result_keys       = ["c", "a"]
+					values            = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.key", "c"),
+				),
 			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("c"),
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					order_by          = "value"
+					result_keys       = ["a", "b", "c"]
+					values            = ["y", "z", "x"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.#", "3"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "c"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.1.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.2.key", "b"),
+				),
 			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringValue("2"),
-				basetypes.NewStringUnknown(),
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					result_keys       = ["a", "b", "c"]
+					result_keys_order = "random"
+					values            = ["1", "2", "3"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)result_keys_order.*value must be one of`),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					order_by          = "value"
+					result_keys       = ["a", "b", "c"]
+					result_keys_order = "input"
+					values            = ["1", "2", "3"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)order_by.*cannot be specified when.*result_keys_order`),
 			},
-			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
-				"a": basetypes.NewStringValue("1"),
-				"c": basetypes.NewStringUnknown(),
-			}),
 		},
-		// not all result keys known
-		{
-			keys: []basetypes.StringValue{
-				basetypes.NewStringValue("a"),
-				basetypes.NewStringValue("b"),
-				basetypes.NewStringValue("c"),
+	})
+}
+
+func TestAccResourceMapResultChunks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					chunk_size  = 2
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "b", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.0.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.0.0.key", "a"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.0.1.key", "b"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.1.#", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.1.0.key", "c"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_chunks.1.0.value", "3"),
+				),
 			},
-			resultKeys: []basetypes.StringValue{
-				basetypes.NewStringUnknown(),
-				basetypes.NewStringValue("c"),
+		},
+	})
+}
+
+func TestAccResourceMapValueFromKeyRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys                 = ["svc-a", "svc-b", "other"]
+					result_keys          = ["svc-a", "svc-b"]
+					value_from_key_regex = "^svc-(.*)$"
+					value_replace        = "service $1"
+					values               = ["1", null, null]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.svc-a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.svc-b", "service b"),
+				),
 			},
-			values: []basetypes.StringValue{
-				basetypes.NewStringValue("1"),
-				basetypes.NewStringValue("2"),
-				basetypes.NewStringValue("3"),
+		},
+	})
+}
+
+func TestAccResourceMapConditionalResultKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					conditional_result_keys = [
+						{ key = "a", include = true },
+						{ key = "b", include = false },
+						{ key = "c", include = true },
+					]
+					keys   = ["a", "b", "c"]
+					values = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.c", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapConditionalAndResultKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					conditional_result_keys = [{ key = "a", include = true }]
+					keys                    = ["a"]
+					result_keys             = ["a"]
+					values                  = ["1"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Invalid Attribute Combination)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapParseValuesAs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys            = ["a", "b", "c"]
+					parse_values_as = "number"
+					result_keys     = ["a", "b"]
+					values          = ["1", "2.5", "x"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.b", "2.5"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys            = ["a", "b"]
+					parse_values_as = "bool"
+					result_keys     = ["a", "b"]
+					values          = ["true", "false"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.a", "true"),
+					resource.TestCheckResourceAttr("resolver_map.test", "parsed_result.b", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapParseValuesAsInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys            = ["a", "b"]
+					parse_values_as = "number"
+					result_keys     = ["a", "b"]
+					values          = ["1", "two"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Value of key "b" is not a valid number)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapInheritFrom(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "base" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", "2"]
+				}
+
+				resource "resolver_map" "test" {
+					inherit_from = resolver_map.base.result
+					keys         = ["b", "c"]
+					result_keys  = ["a", "b", "c"]
+					values       = ["20", "30"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "3"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.b", "20"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.c", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapEncodeValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					encode_values = "hex"
+					keys          = ["a", "b"]
+					result_keys   = ["a"]
+					values        = ["hi", "there"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "6869"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					decode_before_encode = true
+					encode_values        = "hex"
+					keys                 = ["a", "b"]
+					result_keys          = ["a"]
+					values               = ["aGk=", "there"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "6869"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultJSONSchema(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["b", "a"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"resolver_map.test",
+						"result_json_schema",
+						`{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}},"required":["a","b"]}`,
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapValidateOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "resolver" {
+					validate_only = true
+				}
+
+				resource "resolver_map" "test" {
+					chunk_size         = 1
+					compute_complement = true
+					keys               = ["a", "b", "c"]
+					parse_values_as    = "number"
+					result_groups      = { g = ["a"] }
+					result_keys        = ["a", "c"]
+					result_template    = "{{.a}}"
+					values             = ["1", "2", "3"]
+				}
+				`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPreRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("complement")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("key_index")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("key_positions")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("known_result")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("null_safe_result")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("parsed_result")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_as_list")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_by_group")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_chunks")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_csv")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_env_pairs")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_go_map")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_ini")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_json_path")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_json_schema")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_pairs")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_rendered")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_safe")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_without_defaults")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_yaml")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("defaulted_keys")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("resolved_flags")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_count")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_keys_found")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_keys_not_found")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_size_matches_result_keys")),
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("unresolved_reasons")),
+					},
+				},
+			},
+			{
+				Config: `
+				provider "resolver" {
+					validate_only = true
+				}
+
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "c"]
+					values      = ["1", "2"]
+				}
+				`,
+				PlanOnly: true,
+
+				ExpectError: regexp.MustCompile(`(Key count is higher than the number of values)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys            = ["host", "port", "scheme"]
+					result_keys     = ["host", "port"]
+					result_template = "{{.host}}:{{.port}}"
+					values          = ["example.com", "443", "https"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_rendered", "example.com:443"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultTemplateNotResultKey(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys            = ["host", "port", "scheme"]
+					result_keys     = ["host", "port"]
+					result_template = "{{.scheme}}://{{.host}}:{{.port}}"
+					values          = ["example.com", "443", "https"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Template references a key that is not in result_keys)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultEnvPairs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["HOST", "PORT", "USER"]
+					result_keys = ["PORT", "HOST"]
+					values      = ["example.com", "443", "admin"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_env_pairs.#", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_env_pairs.0", "HOST=example.com"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_env_pairs.1", "PORT=443"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultIni(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["b", "a", "c"]
+					result_keys = ["b", "a"]
+					values      = ["2", "1", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_ini", "a = 1\nb = 2\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapUnresolvedReasons(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "unresolved_reasons.%", "0"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b", "c"]
+					result_keys    = ["a", "d"]
+					values         = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "unresolved_reasons.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "unresolved_reasons.d", "missing"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultCSV(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["b", "a", "c"]
+					result_keys = ["b", "a"]
+					values      = ["x, \"y\"", "1", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_csv", "key,value\na,1\nb,\"x, \"\"y\"\"\"\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapBlankIsNull(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					blank_is_null = true
+					keys          = ["a", "b", "c"]
+					result_keys   = ["a", "b"]
+					values        = ["   ", " 2 ", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"resolver_map.test",
+						tfjsonpath.New("result"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.Null(),
+							"b": knownvalue.StringExact(" 2 "),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultYAML(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["b", "a", "c"]
+					result_keys = ["b", "a"]
+					values      = ["yes", "1: one", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("resolver_map.test", "result_yaml", func(value string) error {
+						var parsed map[string]string
+						if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+							return err
+						}
+
+						expected := map[string]string{"a": "1: one", "b": "yes"}
+						if !reflect.DeepEqual(expected, parsed) {
+							return fmt.Errorf("parsed %+v, wanted %+v", parsed, expected)
+						}
+
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapMaxUnknowns(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys         = ["a", "b"]
+					max_unknowns = 1
+					result_keys  = ["a", "c"]
+					values       = ["1", "2"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "unresolved_reasons.c", "missing"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapMaxUnknownsExceeded(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys         = ["a", "b"]
+					max_unknowns = 1
+					result_keys  = ["a", "c", "d"]
+					values       = ["1", "2"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Too many result_keys are unresolved)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeyIndex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["c", "a"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("key_index"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("0"),
+						"c": knownvalue.StringExact("2"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapProviderDefaultValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "resolver" {
+					default_values = {
+						a = "default"
+						b = "default"
+					}
+				}
+
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "b", "c"]
+					values      = ["1", null, null]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("default"),
+						"c": knownvalue.Null(),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeyPositions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["c", "a"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("key_positions"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.Int64Exact(2),
+						knownvalue.Int64Exact(0),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResolvedFlags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b"]
+					result_keys    = ["a", "c"]
+					values         = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("resolved_flags"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.Bool(true),
+						"c": knownvalue.Bool(false),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultGoMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"resolver_map.test", tfjsonpath.New("result"),
+						"resolver_map.test", tfjsonpath.New("result_go_map"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapExpectedResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					expected_result = { a = "1", c = "3" }
+					keys            = ["a", "b", "c"]
+					result_keys     = ["a", "c"]
+					values          = ["1", "2", "3"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.c", "3"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					expected_result = { a = "1", c = "3" }
+					keys            = ["a", "b", "c"]
+					result_keys     = ["a", "c"]
+					values          = ["1", "2", "4"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(Result does not match expected_result)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeepLastGood(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keep_last_good = true
+					keys           = ["a", "b"]
+					result_keys    = ["a"]
+					values         = ["1", "2"]
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keep_last_good = true
+					keys           = ["a", "b"]
+					result_keys    = ["a", "c"]
+					values         = ["10", "2"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a"]
+					values      = ["1", "2"]
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "version", "1"),
+			},
+			// result is unchanged
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a"]
+					values      = ["1", "20"]
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "version", "1"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a"]
+					values      = ["10", "20"]
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "version", "2"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapLastModified(t *testing.T) {
+	lastModifiedSame := statecheck.CompareValue(compare.ValuesSame())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a"]
+					values      = ["1", "2"]
+				}
+				`,
+				Check: resource.TestMatchResourceAttr("resolver_map.test", "last_modified", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+				ConfigStateChecks: []statecheck.StateCheck{
+					lastModifiedSame.AddStateValue("resolver_map.test", tfjsonpath.New("last_modified")),
+				},
+			},
+			// result is unchanged
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a"]
+					values      = ["1", "20"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					lastModifiedSame.AddStateValue("resolver_map.test", tfjsonpath.New("last_modified")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapFallbackSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "resolver" {
+					default_values = {
+						c = "default"
+					}
+				}
+
+				resource "resolver_map" "test" {
+					fallback_source = { a = "fallback", b = "fallback" }
+					keys            = ["a"]
+					result_keys     = ["a", "b", "c", "d"]
+					values          = ["1"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("fallback"),
+						"c": knownvalue.StringExact("default"),
+						"d": knownvalue.Null(),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultCount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b"]
+					result_keys    = ["a", "b", "c"]
+					values         = ["1", "2"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_count.missing", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_count.resolved", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_count.total", "3"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_count.unknown", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapChangedKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("changed_keys"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"),
+						knownvalue.StringExact("c"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["b", "c"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("changed_keys"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"),
+						knownvalue.StringExact("b"),
+					})),
+				},
+			},
+			// result is unchanged, so the last change is kept
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["b", "c"]
+					values      = ["10", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("changed_keys"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("a"),
+						knownvalue.StringExact("b"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapTrimPrefixAndSuffix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					trim_prefix = "arn:aws:iam::123456789012:role/"
+					trim_suffix = "-role"
+					values      = ["arn:aws:iam::123456789012:role/admin-role", "reader"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "admin"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.b", "reader"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapReplaceInValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["bucket", "table"]
+					result_keys = ["bucket", "table"]
+					values      = ["__ENV__-assets", "__ENV___orders"]
+
+					replace_in_values = [
+						{ from = "__ENV__", to = "staging" },
+						{ from = "staging_", to = "staging." },
+					]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.bucket", "staging-assets"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.table", "staging.orders"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapTransforms(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "b", "c"]
+					values      = ["Hello", "secret", "Kept"]
+
+					transforms = {
+						a = "upper"
+						b = "base64encode"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "HELLO"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.b", "c2VjcmV0"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.c", "Kept"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["Hello"]
+
+					transforms = {
+						a = "reverse"
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("value must be one of"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapReplaceInKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["db-host", "db-port", "cache-host"]
+					result_keys = ["db-host", "db-port"]
+					values      = ["10.0.0.1", "5432", "10.0.0.2"]
+
+					replace_in_keys = [
+						{ from = "db-", to = "DATABASE_" },
+						{ from = "host", to = "HOST" },
+						{ from = "port", to = "PORT" },
+					]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.DATABASE_HOST", "10.0.0.1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.DATABASE_PORT", "5432"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result_pairs.0.key", "DATABASE_HOST"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["db-host", "db_host"]
+					result_keys = ["db-host", "db_host"]
+					values      = ["10.0.0.1", "10.0.0.2"]
+
+					replace_in_keys = [
+						{ from = "-", to = "_" },
+					]
+				}
+				`,
+				ExpectError: regexp.MustCompile("Replaced result keys collide"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeyCountRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a"]
+					values      = ["1", "2", "3"]
+					min_keys    = 2
+				}
+				`,
+				ExpectError: regexp.MustCompile("Result has too few keys"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "b", "c"]
+					values      = ["1", "2", "3"]
+					max_keys    = 2
+				}
+				`,
+				ExpectError: regexp.MustCompile("Result has too many keys"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a", "b"]
+					values      = ["1", "2", "3"]
+					min_keys    = 2
+					max_keys    = 2
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.%", "2"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultJSONPath(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["host", "db.port", "user"]
+					result_keys = ["host", "db.port"]
+					values      = ["localhost", "5432", "admin"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result_json_path", "$.host;$['db.port']"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccResourceMapResultStability guards against result depending on the order keys are given in, such as through
+// map iteration order, which would show as a diff in result on every plan.
+func TestAccResourceMapResultStability(t *testing.T) {
+	resultSame := statecheck.CompareValue(compare.ValuesSame())
+	versionSame := statecheck.CompareValue(compare.ValuesSame())
+	expectedResult := knownvalue.MapExact(map[string]knownvalue.Check{
+		"k0": knownvalue.StringExact("v0"),
+		"k1": knownvalue.StringExact("v1"),
+		"k2": knownvalue.StringExact("v2"),
+		"k3": knownvalue.StringExact("v3"),
+		"k4": knownvalue.StringExact("v4"),
+		"k5": knownvalue.StringExact("v5"),
+		"k6": knownvalue.StringExact("v6"),
+		"k7": knownvalue.StringExact("v7"),
+		"k8": knownvalue.StringExact("v8"),
+		"k9": knownvalue.StringExact("v9"),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["k3", "k7", "k0", "k9", "k5", "k1", "k8", "k2", "k6", "k4"]
+					result_keys = ["k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9"]
+					values      = ["v3", "v7", "v0", "v9", "v5", "v1", "v8", "v2", "v6", "v4"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), expectedResult),
+					resultSame.AddStateValue("resolver_map.test", tfjsonpath.New("result")),
+					versionSame.AddStateValue("resolver_map.test", tfjsonpath.New("version")),
+				},
+			},
+			// the same pairs in a different order leave result as it is
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["k6", "k2", "k9", "k4", "k0", "k8", "k1", "k7", "k3", "k5"]
+					result_keys = ["k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9"]
+					values      = ["v6", "v2", "v9", "v4", "v0", "v8", "v1", "v7", "v3", "v5"]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), expectedResult),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					resultSame.AddStateValue("resolver_map.test", tfjsonpath.New("result")),
+					versionSame.AddStateValue("resolver_map.test", tfjsonpath.New("version")),
+				},
+			},
+			// planning again is empty
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["k6", "k2", "k9", "k4", "k0", "k8", "k1", "k7", "k3", "k5"]
+					result_keys = ["k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9"]
+					values      = ["v6", "v2", "v9", "v4", "v0", "v8", "v1", "v7", "v3", "v5"]
+				}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeyValidationRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["us-east-1", "EU_WEST"]
+					result_keys = ["us-east-1"]
+					values      = ["1", "2"]
+
+					key_validation_regex   = "^[a-z0-9-]+$"
+					key_validation_message = "Keys must be lowercase region names"
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Keys must be lowercase region names`),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["us-east-1", "eu-west-1"]
+					result_keys = ["us-east-1"]
+					values      = ["1", "2"]
+
+					key_validation_regex   = "^[a-z0-9-]+$"
+					key_validation_message = "Keys must be lowercase region names"
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.us-east-1", "1"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapErrorIfUnknownAfter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			// before the deadline
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys         = ["a"]
+					result_keys  = ["a", "b"]
+					values       = ["1"]
+					max_unknowns = 1
+
+					error_if_unknown_after = "2999-01-01T00:00:00Z"
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.%", "1"),
+			},
+			// after the deadline
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys         = ["a"]
+					result_keys  = ["a", "b"]
+					values       = ["1"]
+					max_unknowns = 1
+
+					error_if_unknown_after = "2000-01-01T00:00:00Z"
+				}
+				`,
+				ExpectError: regexp.MustCompile("Result keys are unresolved after the deadline"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+
+					error_if_unknown_after = "tomorrow"
+				}
+				`,
+				ExpectError: regexp.MustCompile("Invalid timestamp"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapValueValidationRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["admin", "reader"]
+					result_keys = ["admin"]
+					values      = ["arn:aws:iam::123456789012:role/admin", "reader"]
+
+					value_validation_regex   = "^arn:aws:.*"
+					value_validation_message = "Values must be ARNs"
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Values must be ARNs`),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["admin", "reader"]
+					result_keys = ["admin"]
+					values      = ["arn:aws:iam::123456789012:role/admin", null]
+
+					value_validation_regex = "^arn:aws:.*"
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.admin", "arn:aws:iam::123456789012:role/admin"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKeySource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					key_source  = { "1" = "b", "0" = "a" }
+					result_keys = ["a", "b"]
+					values      = ["first", "second"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "first"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.b", "second"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					key_source  = { "0" = "a" }
+					result_keys = ["a"]
+					values      = ["first", "second"]
+				}
+				`,
+				ExpectError: regexp.MustCompile("Key count is lower than the number of values"),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					key_source  = { "0" = "a" }
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["first"]
+				}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultKeyValidationRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["DB_HOST", "db.port"]
+					result_keys = ["DB_HOST", "db.port"]
+					values      = ["localhost", "5432"]
+
+					result_key_validation_regex = "^[A-Z_]+$"
+				}
+				`,
+				ExpectError: regexp.MustCompile(`"db.port" do not match`),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["DB_HOST", "db.port"]
+					result_keys = ["DB_HOST"]
+					values      = ["localhost", "5432"]
+
+					result_key_validation_regex = "^[A-Z_]+$"
+				}
+				`,
+				Check: resource.TestCheckResourceAttr("resolver_map.test", "result.DB_HOST", "localhost"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapDescription(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					description = "Regional endpoints"
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "description", "Regional endpoints"),
+					resource.TestCheckResourceAttr("resolver_map.test", "version", "1"),
+				),
+			},
+			// only the description changes
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					description = "Regional endpoints, by region name"
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_map.test", "description", "Regional endpoints, by region name"),
+					resource.TestCheckResourceAttr("resolver_map.test", "result.a", "1"),
+					resource.TestCheckResourceAttr("resolver_map.test", "version", "1"),
+				),
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+				}
+				`,
+				Check: resource.TestCheckNoResourceAttr("resolver_map.test", "description"),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+
+					tags = {
+						team        = "platform"
+						cost_center = "1234"
+					}
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_tags"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"cost_center": knownvalue.StringExact("1234"),
+						"team":        knownvalue.StringExact("platform"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_tags"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapNullSafeResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", null]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.Null(),
+					})),
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("null_safe_result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact(""),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultWithoutDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					default_value = "unset"
+					keys          = ["a"]
+					result_keys   = ["a", "b", "c"]
+					values        = ["1"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("unset"),
+						"c": knownvalue.StringExact("unset"),
+					})),
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_without_defaults"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a"]
+					result_keys = ["a"]
+					values      = ["1"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_without_defaults"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapCoerceResultKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					coerce_result_keys = true
+					keys               = [1, 2.5, "c"]
+					result_keys        = ["01", "2.50", "c"]
+					values             = ["a", "b", "c"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"01":   knownvalue.StringExact("a"),
+						"2.50": knownvalue.StringExact("b"),
+						"c":    knownvalue.StringExact("c"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapDefaultedKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					default_value = "unset"
+					keys          = ["b"]
+					result_keys   = ["c", "b", "a"]
+					values        = ["2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("defaulted_keys"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("c"),
+						knownvalue.StringExact("a"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["b"]
+					result_keys = ["b"]
+					values      = ["2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("defaulted_keys"), knownvalue.ListExact([]knownvalue.Check{})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapNullResultKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys   = ["a", "b"]
+					values = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"b": knownvalue.StringExact("2"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = []
+					values      = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultSizeMatchesResultKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_size_matches_result_keys"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a"]
+					result_keys    = ["a", "b"]
+					values         = ["1"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_size_matches_result_keys"), knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultSafe(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "terraform_data" "test" {}
+
+				resource "resolver_map" "test" {
+					keys                = ["a", "b"]
+					result_keys         = ["a", "b"]
+					unknown_placeholder = "pending"
+					values              = ["1", terraform_data.test.id]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result").AtMapKey("b")),
+						plancheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_safe"), knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.StringExact("1"),
+							"b": knownvalue.StringExact("pending"),
+						})),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// The placeholder is replaced with the applied value on refresh.
+			{
+				Config: `
+				resource "terraform_data" "test" {}
+
+				resource "resolver_map" "test" {
+					keys                = ["a", "b"]
+					result_keys         = ["a", "b"]
+					unknown_placeholder = "pending"
+					values              = ["1", terraform_data.test.id]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs("resolver_map.test", tfjsonpath.New("result_safe").AtMapKey("b"), "terraform_data.test", tfjsonpath.New("id"), compare.ValuesSame()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultKeysFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b", "c"]
+					result_keys    = ["c", "d", "a"]
+					values         = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_keys_found"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("c"),
+						knownvalue.StringExact("a"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapValueIsDuration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys                = ["read", "write"]
+					normalize_durations = true
+					result_keys         = ["read", "write"]
+					values              = ["90s", "1h"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"read":  knownvalue.StringExact("1m30s"),
+						"write": knownvalue.StringExact("1h0m0s"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys              = ["read", "write"]
+					result_keys       = ["read", "write"]
+					value_is_duration = true
+					values            = ["90s", "soon"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(Value of key "write" is not a valid duration)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultKeysNotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					collect_errors = true
+					keys           = ["a", "b"]
+					result_keys    = ["d", "a", "c"]
+					values         = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_keys_not_found"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("d"),
+						knownvalue.StringExact("c"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapImportState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", "2"]
+				}
+				`,
+			},
+			{
+				ResourceName: "resolver_map.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return `{"b": "2", "a": "1"}`, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					expected := map[string]string{
+						"keys.#":        "2",
+						"keys.0":        "a",
+						"keys.1":        "b",
+						"result.%":      "2",
+						"result.a":      "1",
+						"result.b":      "2",
+						"result_keys.#": "2",
+						"result_keys.0": "a",
+						"result_keys.1": "b",
+						"values.#":      "2",
+						"values.0":      "1",
+						"values.1":      "2",
+					}
+
+					for key, value := range expected {
+						if states[0].Attributes[key] != value {
+							return fmt.Errorf("imported %s as %q, wanted %q", key, states[0].Attributes[key], value)
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapForbiddenValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					forbidden_values = ["CHANGEME"]
+					keys             = ["a", "b"]
+					result_keys      = ["a"]
+					values           = ["1", "CHANGEME"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)(Value is forbidden).*(key "b")`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapComputeComplement(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					compute_complement = true
+					keys               = ["a", "b", "c"]
+					result_keys        = ["a"]
+					values             = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("complement"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"b": knownvalue.StringExact("2"),
+						"c": knownvalue.StringExact("3"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = ["a"]
+					values      = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("complement"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys        = ["a", "b", "c"]
+					result_keys = []
+					result_groups = {
+						first  = ["a", "b"]
+						second = ["c"]
+					}
+					values = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_by_group"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"first": knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.StringExact("1"),
+							"b": knownvalue.StringExact("2"),
+						}),
+						"second": knownvalue.MapExact(map[string]knownvalue.Check{
+							"c": knownvalue.StringExact("3"),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapStripResultKeyPrefix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys                    = ["app_a", "app_b", "c"]
+					result_keys             = ["app_a", "c"]
+					strip_result_key_prefix = "app_"
+					values                  = ["1", "2", "3"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+						"c": knownvalue.StringExact("3"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys                    = ["app_a", "a"]
+					result_keys             = ["app_a", "a"]
+					strip_result_key_prefix = "app_"
+					values                  = ["1", "2"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`(Stripped result keys collide)`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapResultAsList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "terraform_data" "test" {}
+
+				resource "resolver_map" "test" {
+					keys              = ["a", "b", "c"]
+					result_keys       = ["c", "a"]
+					result_keys_order = "lexicographic"
+					values            = ["1", "2", terraform_data.test.id]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("resolver_map.test", tfjsonpath.New("result_as_list").AtSliceIndex(0).AtMapKey("value")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result_as_list"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":   knownvalue.StringExact("c"),
+							"value": knownvalue.NotNull(),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"key":   knownvalue.StringExact("a"),
+							"value": knownvalue.StringExact("1"),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapKnownResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "terraform_data" "test" {}
+
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", terraform_data.test.id]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("known_result"), knownvalue.MapExact(map[string]knownvalue.Check{
+							"a": knownvalue.StringExact("1"),
+						})),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// The entries left out at plan are completed on refresh.
+			{
+				Config: `
+				resource "terraform_data" "test" {}
+
+				resource "resolver_map" "test" {
+					keys        = ["a", "b"]
+					result_keys = ["a", "b"]
+					values      = ["1", terraform_data.test.id]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("known_result"), knownvalue.MapSizeExact(2)),
+					statecheck.CompareValuePairs("resolver_map.test", tfjsonpath.New("known_result").AtMapKey("b"), "terraform_data.test", tfjsonpath.New("id"), compare.ValuesSame()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapPlanWarningOnEmptyResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			// The warning does not block the plan or apply.
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					keys                         = ["a", "b"]
+					plan_warning_on_empty_result = true
+					result_keys                  = []
+					values                       = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapSizeExact(0)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceMapAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					aliases     = { prod = "production", live = "prod" }
+					keys        = ["production", "staging"]
+					result_keys = ["live", "staging"]
+					values      = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"live":    knownvalue.StringExact("1"),
+						"staging": knownvalue.StringExact("2"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					aliases     = { prod = "live", live = "prod" }
+					keys        = ["production"]
+					result_keys = ["prod"]
+					values      = ["1"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Cyclic aliases`),
+			},
+		},
+	})
+}
+
+func TestAccResourceMapFailOnEmptyResult(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					fail_on_empty_result = true
+					keys                 = ["a", "b"]
+					result_keys          = ["a"]
+					values               = ["1", "2"]
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("resolver_map.test", tfjsonpath.New("result"), knownvalue.MapExact(map[string]knownvalue.Check{
+						"a": knownvalue.StringExact("1"),
+					})),
+				},
+			},
+			{
+				Config: `
+				resource "resolver_map" "test" {
+					fail_on_empty_result = true
+					keys                 = ["a", "b"]
+					result_keys          = []
+					values               = ["1", "2"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Result map is empty`),
+			},
+		},
+	})
+}
+
+func TestInternalResolveMap(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.MapValue
+	}{
+		// basic cases
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// a known value of a duplicate key wins over an unknown one, in either order
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("x"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("y"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("x"),
+				"b": basetypes.NewStringValue("y"),
+			}),
+		},
+		// otherwise the last value of a duplicate key wins
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("x"),
+				basetypes.NewStringValue("y"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("y"),
+			}),
+		},
+		// some keys unknown, but all result keys known
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// some key value pairs unknown, but all result keys known
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// some key values unknown, but all result keys known
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// some key values unknown, including some for result keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// nothing to resolve
+		{
+			keys:           []basetypes.StringValue{},
+			resultKeys:     []basetypes.StringValue{},
+			values:         []basetypes.StringValue{},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		// not all result keys known
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v,%+v", test.keys, test.resultKeys, test.values, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolveMap(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolvePartialResult(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.MapValue
+	}{
+		// missing result keys are left out
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		// missing result key may be one of the unknown keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v,%+v", test.keys, test.resultKeys, test.values, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolve(test.keys, test.resultKeys, test.values).partialResult()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionOverwrite(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringUnknown(),
+	}
+
+	var tests = []struct {
+		resultKeys     []basetypes.StringValue
+		overrides      basetypes.MapValue
+		expectedResult basetypes.MapValue
+	}{
+		// known override of an unknown value
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			overrides: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+		},
+		// unknown override of a known value
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			overrides: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+		},
+		// override of a result key that is not in keys
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			overrides: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("3"),
+				"d": basetypes.NewStringValue("4"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// unknown overrides
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			overrides:      basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.resultKeys, test.overrides, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(keys, test.resultKeys, values)
+			res.overwrite(test.overrides)
+			actualResult := res.result()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalNormalizeKeys(t *testing.T) {
+	var tests = []struct {
+		transforms     []string
+		keys           []basetypes.StringValue
+		expectedKeys   []basetypes.StringValue
+		expectedErrors int
+	}{
+		// transforms are applied in order
+		{
+			transforms: []string{"trim", "lower"},
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue(" A "),
+				basetypes.NewStringUnknown(),
+			},
+			expectedKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// decomposed and precomposed forms are the same after NFC normalization
+		{
+			transforms: []string{"nfc"},
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("e\u0301"),
+				basetypes.NewStringValue("f"),
+			},
+			expectedKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("\u00e9"),
+				basetypes.NewStringValue("f"),
+			},
+		},
+		// collision between different keys
+		{
+			transforms: []string{"nfc", "lower"},
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("\u00c9"),
+				basetypes.NewStringValue("e\u0301"),
+			},
+			expectedKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("\u00e9"),
+				basetypes.NewStringValue("\u00e9"),
+			},
+			expectedErrors: 1,
+		},
+		// repeated keys are not a collision
+		{
+			transforms: []string{"lower"},
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+			expectedKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.transforms, test.keys)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+
+			names := make([]attr.Value, len(test.transforms))
+			for i, name := range test.transforms {
+				names[i] = basetypes.NewStringValue(name)
+			}
+
+			transforms, _ := keyNormalizationTransforms(basetypes.NewListValueMust(types.StringType, names), &diagnostics)
+			actualKeys := normalizeKeys(path.Root("keys"), test.keys, transforms, &diagnostics)
+
+			if !reflect.DeepEqual(test.expectedKeys, actualKeys) {
+				t.Errorf("Got %+v, wanted %+v", actualKeys, test.expectedKeys)
+			}
+
+			if diagnostics.ErrorsCount() != test.expectedErrors {
+				t.Errorf("Got %d errors, wanted %d", diagnostics.ErrorsCount(), test.expectedErrors)
+			}
+		})
+	}
+}
+
+func TestInternalOrderResultKeys(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("3"),
+		basetypes.NewStringValue("2"),
+	}
+
+	var tests = []struct {
+		order          string
+		expectedResult []string
+		expectedOk     bool
+	}{
+		{
+			order:          "input",
+			expectedResult: []string{"b", "c", "a"},
+			expectedOk:     true,
+		},
+		{
+			order:          "key",
+			expectedResult: []string{"a", "b", "c"},
+			expectedOk:     true,
+		},
+		{
+			order:          "keys_input",
+			expectedResult: []string{"c", "a", "b"},
+			expectedOk:     true,
+		},
+		{
+			order:          "lexicographic",
+			expectedResult: []string{"a", "b", "c"},
+			expectedOk:     true,
+		},
+		{
+			order:          "reverse",
+			expectedResult: []string{"a", "c", "b"},
+			expectedOk:     true,
+		},
+		{
+			order:          "value",
+			expectedResult: []string{"c", "b", "a"},
+			expectedOk:     true,
+		},
+		{
+			order: "random",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.order, func(t *testing.T) {
+			actualResult, actualOk := orderResultKeys(resolve(keys, resultKeys, values), keys, test.order)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) || test.expectedOk != actualOk {
+				t.Errorf("Got %+v %t, wanted %+v %t", actualResult, actualOk, test.expectedResult, test.expectedOk)
+			}
+		})
+	}
+}
+
+func TestInternalChunkPairs(t *testing.T) {
+	pair := func(key string) attr.Value {
+		return basetypes.NewObjectValueMust(resultPairType.AttrTypes, map[string]attr.Value{
+			"key":   basetypes.NewStringValue(key),
+			"value": basetypes.NewStringUnknown(),
+		})
+	}
+	chunk := func(pairs ...attr.Value) attr.Value {
+		return basetypes.NewListValueMust(resultPairType, pairs)
+	}
+	chunks := func(chunks ...attr.Value) basetypes.ListValue {
+		return basetypes.NewListValueMust(types.ListType{ElemType: resultPairType}, chunks)
+	}
+
+	var tests = []struct {
+		pairs          []attr.Value
+		size           int
+		expectedResult basetypes.ListValue
+	}{
+		// even split
+		{
+			pairs:          []attr.Value{pair("a"), pair("b"), pair("c"), pair("d")},
+			size:           2,
+			expectedResult: chunks(chunk(pair("a"), pair("b")), chunk(pair("c"), pair("d"))),
+		},
+		// uneven split
+		{
+			pairs:          []attr.Value{pair("a"), pair("b"), pair("c")},
+			size:           2,
+			expectedResult: chunks(chunk(pair("a"), pair("b")), chunk(pair("c"))),
+		},
+		// larger than the pairs
+		{
+			pairs:          []attr.Value{pair("a")},
+			size:           5,
+			expectedResult: chunks(chunk(pair("a"))),
+		},
+		// no pairs
+		{
+			pairs:          []attr.Value{},
+			size:           2,
+			expectedResult: chunks(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%d", test.pairs, test.size)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := chunkPairs(basetypes.NewListValueMust(resultPairType, test.pairs), test.size)
+
+			if !actualResult.Equal(test.expectedResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalDuplicateKeys(t *testing.T) {
+	var tests = []struct {
+		keys               []basetypes.StringValue
+		expectedDuplicates []string
+	}{
+		// no duplicates
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+		},
+		// duplicates reported once each
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+			},
+			expectedDuplicates: []string{`"a"`, `"b"`},
+		},
+		// unknown keys are not duplicates
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.keys)
+
+		t.Run(testname, func(t *testing.T) {
+			actualDuplicates := duplicateKeys(test.keys)
+
+			if !reflect.DeepEqual(test.expectedDuplicates, actualDuplicates) {
+				t.Errorf("Got %+v, wanted %+v", actualDuplicates, test.expectedDuplicates)
+			}
+		})
+	}
+}
+
+func TestInternalValuesFromKeys(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("svc-a"),
+		basetypes.NewStringValue("svc-b"),
+		basetypes.NewStringValue("other"),
+		basetypes.NewStringUnknown(),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringNull(),
+		basetypes.NewStringNull(),
+		basetypes.NewStringNull(),
+	}
+
+	var tests = []struct {
+		regex          *regexp.Regexp
+		replace        string
+		expectedValues []basetypes.StringValue
+	}{
+		// replacement template
+		{
+			regex:   regexp.MustCompile(`^svc-(?P<name>.*)$`),
+			replace: "${name}-service",
+			expectedValues: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("b-service"),
+				basetypes.NewStringNull(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// only the matches are replaced
+		{
+			regex:   regexp.MustCompile(`svc-`),
+			replace: "",
+			expectedValues: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringNull(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// regex not known yet
+		{
+			expectedValues: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%v,%s", test.regex, test.replace)
+
+		t.Run(testname, func(t *testing.T) {
+			actualValues := valuesFromKeys(keys, values, test.regex, test.replace)
+
+			if !reflect.DeepEqual(test.expectedValues, actualValues) {
+				t.Errorf("Got %+v, wanted %+v", actualValues, test.expectedValues)
+			}
+		})
+	}
+}
+
+func TestInternalConditionalResultKeys(t *testing.T) {
+	conditionalKey := func(key basetypes.StringValue, include basetypes.BoolValue) attr.Value {
+		return basetypes.NewObjectValueMust(conditionalResultKeyType.AttrTypes, map[string]attr.Value{
+			"include": include,
+			"key":     key,
+		})
+	}
+
+	var tests = []struct {
+		conditionalKeys    basetypes.ListValue
+		expectedResultKeys []basetypes.StringValue
+	}{
+		// included and excluded
+		{
+			conditionalKeys: basetypes.NewListValueMust(conditionalResultKeyType, []attr.Value{
+				conditionalKey(basetypes.NewStringValue("a"), basetypes.NewBoolValue(true)),
+				conditionalKey(basetypes.NewStringValue("b"), basetypes.NewBoolValue(false)),
+				conditionalKey(basetypes.NewStringUnknown(), basetypes.NewBoolValue(true)),
+			}),
+			expectedResultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// unknown include
+		{
+			conditionalKeys: basetypes.NewListValueMust(conditionalResultKeyType, []attr.Value{
+				conditionalKey(basetypes.NewStringValue("a"), basetypes.NewBoolUnknown()),
+			}),
+			expectedResultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// unknown list
+		{
+			conditionalKeys: basetypes.NewListUnknown(conditionalResultKeyType),
+			expectedResultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.conditionalKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResultKeys := conditionalResultKeys(test.conditionalKeys)
+
+			if !reflect.DeepEqual(test.expectedResultKeys, actualResultKeys) {
+				t.Errorf("Got %+v, wanted %+v", actualResultKeys, test.expectedResultKeys)
+			}
+		})
+	}
+}
+
+func TestInternalParseValues(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		as             string
+		expectedParsed basetypes.MapValue
+		expectedErrors int
+	}{
+		// numbers, with unknown and null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("-2.5e3"),
+				"c": basetypes.NewStringUnknown(),
+				"d": basetypes.NewStringNull(),
+			}),
+			as: "number",
+			expectedParsed: basetypes.NewMapValueMust(types.NumberType, map[string]attr.Value{
+				"a": basetypes.NewNumberValue(big.NewFloat(1)),
+				"b": basetypes.NewNumberValue(big.NewFloat(-2500)),
+				"c": basetypes.NewNumberUnknown(),
+				"d": basetypes.NewNumberNull(),
+			}),
+		},
+		// bools
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("true"),
+				"b": basetypes.NewStringValue("false"),
+			}),
+			as: "bool",
+			expectedParsed: basetypes.NewMapValueMust(types.BoolType, map[string]attr.Value{
+				"a": basetypes.NewBoolValue(true),
+				"b": basetypes.NewBoolValue(false),
+			}),
+		},
+		// values that cannot be parsed
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("Inf"),
+				"c": basetypes.NewStringValue("one"),
+			}),
+			as:             "number",
+			expectedParsed: basetypes.NewMapNull(types.NumberType),
+			expectedErrors: 2,
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("yes"),
+			}),
+			as:             "bool",
+			expectedParsed: basetypes.NewMapNull(types.BoolType),
+			expectedErrors: 1,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.as)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualParsed, _ := parseValues(test.result, test.as, &diagnostics)
+
+			if !actualParsed.Equal(test.expectedParsed) {
+				t.Errorf("Got %+v, wanted %+v", actualParsed, test.expectedParsed)
+			}
+
+			if diagnostics.ErrorsCount() != test.expectedErrors {
+				t.Errorf("Got %d errors, wanted %d: %+v", diagnostics.ErrorsCount(), test.expectedErrors, diagnostics)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionInherit(t *testing.T) {
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("2"),
+	}
+
+	var tests = []struct {
+		keys, resultKeys []basetypes.StringValue
+		base             basetypes.MapValue
+		expectedResult   basetypes.MapValue
+	}{
+		// keys take precedence over inherited values
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			base: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("10"),
+				"c": basetypes.NewStringValue("30"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("30"),
+			}),
+		},
+		// result key in neither keys nor base
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("d"),
+			},
+			base: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("30"),
+			}),
+			expectedResult: basetypes.NewMapNull(types.StringType),
+		},
+		// an unknown key may override the inherited value
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			base: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("30"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown base is only needed for result keys not in keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			base: basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("c"),
+			},
+			base:           basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v,%+v", test.keys, test.resultKeys, test.base, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(test.keys, test.resultKeys, values)
+			res.inherit(test.base)
+			actualResult := res.result()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalOrderResultKeysStable(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringValue("d"),
+	}
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("d"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringValue("a"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("2"),
+		basetypes.NewStringNull(),
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("1"),
+	}
+
+	// Equal values keep the order of result_keys, with null values first.
+	actualResult, _ := orderResultKeys(resolve(keys, resultKeys, values), keys, "value")
+	expectedResult := []string{"b", "d", "c", "a"}
+
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		t.Errorf("Got %+v, wanted %+v", actualResult, expectedResult)
+	}
+}
+
+func TestInternalEncodeValues(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		encoding       string
+		decode         bool
+		expectedResult basetypes.MapValue
+		expectedErrors int
+	}{
+		// each encoding, with unknown and null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("hi?"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+			}),
+			encoding: "base64",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk/"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+			}),
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("hi?"),
+			}),
+			encoding: "base64url",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk_"),
+			}),
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("hi?"),
+			}),
+			encoding: "hex",
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("68693f"),
+			}),
+		},
+		// transcoding
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk/"),
+			}),
+			encoding: "base64url",
+			decode:   true,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk_"),
+			}),
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk/"),
+			}),
+			encoding: "none",
+			decode:   true,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("hi?"),
+			}),
+		},
+		// values that cannot be decoded
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("aGk/"),
+				"b": basetypes.NewStringValue("not base64"),
+			}),
+			encoding: "hex",
+			decode:   true,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("68693f"),
+			}),
+			expectedErrors: 1,
+		},
+		// unknown result
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			encoding:       "hex",
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%t", test.result, test.encoding, test.decode)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualResult, _ := encodeValues(test.result, test.encoding, test.decode, &diagnostics)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+
+			if diagnostics.ErrorsCount() != test.expectedErrors {
+				t.Errorf("Got %d errors, wanted %d: %+v", diagnostics.ErrorsCount(), test.expectedErrors, diagnostics)
+			}
+		})
+	}
+}
+
+func TestInternalResultJSONSchema(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           string
+	}{
+		// unknown values
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: `{"type":"object","properties":{"a":{"type":"string"},"b":{"type":"string"}},"required":["a","b"]}`,
+		},
+		// no result keys
+		{
+			expectedResult: `{"type":"object"}`,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resultJSONSchema(resolve(test.keys, test.resultKeys, test.values))
+
+			if test.expectedResult != actualResult {
+				t.Errorf("Got %s, wanted %s", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalRenderTemplate(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+	}
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+
+	var tests = []struct {
+		text           string
+		values         []basetypes.StringValue
+		expectedResult basetypes.StringValue
+		expectedOk     bool
+	}{
+		// known values
+		{
+			text: "{{.a}}-{{if .b}}{{$.b}}{{end}}",
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewStringValue("1-2"),
+			expectedOk:     true,
+		},
+		// unknown value that is referenced
+		{
+			text: "{{.a}}",
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewStringUnknown(),
+			expectedOk:     true,
+		},
+		// unknown value that is not referenced
+		{
+			text: "{{.b}}",
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewStringValue("2"),
+			expectedOk:     true,
+		},
+		// key that is not a result key, including in a branch
+		{
+			text: "{{with .a}}{{$.c}}{{end}}",
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewStringNull(),
+		},
+		// invalid template
+		{
+			text: "{{.a",
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: basetypes.NewStringNull(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.text, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			res := resolve(keys, resultKeys, test.values)
+			actualResult, actualOk := renderTemplate(test.text, res, res.result(), &diagnostics)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) || test.expectedOk != actualOk {
+				t.Errorf("Got %+v %t, wanted %+v %t", actualResult, actualOk, test.expectedResult, test.expectedOk)
+			}
+
+			if diagnostics.HasError() == test.expectedOk {
+				t.Errorf("Got errors %+v", diagnostics)
+			}
+		})
+	}
+}
+
+func TestInternalEnvPairs(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		expectedResult basetypes.ListValue
+	}{
+		// sorted, with unknown and null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"B": basetypes.NewStringValue("2=two"),
+				"A": basetypes.NewStringUnknown(),
+				"C": basetypes.NewStringNull(),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("B=2=two"),
+				basetypes.NewStringValue("C="),
+			}),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewListUnknown(types.StringType),
+		},
+		{
+			result:         basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewListNull(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := envPairs(test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalIniResult(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		expectedResult basetypes.StringValue
+	}{
+		// sorted, with unknown and null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("2"),
+				"a": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+			}),
+			expectedResult: basetypes.NewStringValue("a = __UNKNOWN__\nb = 2\nc = \n"),
+		},
+		// a value cannot add lines
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1\nb = 2\r\n[section]\\"),
+			}),
+			expectedResult: basetypes.NewStringValue(`a = 1\nb = 2\r\n[section]\\` + "\n"),
+		},
+		// a key cannot end early or add lines
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a=b":  basetypes.NewStringValue("1"),
+				"c\nd": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewStringValue(`a\=b = 1` + "\n" + `c\nd = 2` + "\n"),
+		},
+		{
+			result:         basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			expectedResult: basetypes.NewStringValue(""),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		{
+			result:         basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewStringNull(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := iniResult(test.result, "__UNKNOWN__")
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalUnresolvedReasons(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.MapValue
+	}{
+		// missing and value unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("value_unknown"),
+				"c": basetypes.NewStringValue("missing"),
+			}),
+		},
+		// key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("key_unknown"),
+			}),
+		},
+		// result key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolve(test.keys, test.resultKeys, test.values).unresolvedReasons()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalCSVResult(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		expectedResult basetypes.StringValue
+	}{
+		// sorted and escaped, with null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("line\nbreak"),
+				"b": basetypes.NewStringValue(`say "hi", then leave`),
+				"a": basetypes.NewStringNull(),
+			}),
+			expectedResult: basetypes.NewStringValue("key,value\na,\nb,\"say \"\"hi\"\", then leave\"\nc,\"line\nbreak\"\n"),
+		},
+		{
+			result:         basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			expectedResult: basetypes.NewStringValue("key,value\n"),
+		},
+		// unknown value
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := csvResult(test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalBlankToNull(t *testing.T) {
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue(" \t\n"),
+		basetypes.NewStringValue(""),
+		basetypes.NewStringValue(" a "),
+		basetypes.NewStringUnknown(),
+		basetypes.NewStringNull(),
+	}
+
+	var tests = []struct {
+		unknown        bool
+		expectedResult []basetypes.StringValue
+	}{
+		{
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringNull(),
+				basetypes.NewStringNull(),
+				basetypes.NewStringValue(" a "),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringNull(),
+			},
+		},
+		{
+			unknown: true,
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue(" a "),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringNull(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%t", test.unknown)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := blankToNull(values, test.unknown)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalYAMLResult(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		expectedResult basetypes.StringValue
+	}{
+		// sorted and quoted where needed, with null values
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"c": basetypes.NewStringValue("plain"),
+				"b": basetypes.NewStringValue("true"),
+				"a": basetypes.NewStringNull(),
+			}),
+			expectedResult: basetypes.NewStringValue("a: null\nb: \"true\"\nc: plain\n"),
+		},
+		// special characters are quoted or use block scalars
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a: b":      basetypes.NewStringValue("x: y"),
+				"comment":   basetypes.NewStringValue("# not a comment"),
+				"empty":     basetypes.NewStringValue(""),
+				"multiline": basetypes.NewStringValue("line 1\nline 2"),
+				"number":    basetypes.NewStringValue("0123"),
+				"quote":     basetypes.NewStringValue(`it's "quoted"`),
+				"space":     basetypes.NewStringValue(" padded "),
+				"unicode":   basetypes.NewStringValue("héllo ✓"),
+				"yes":       basetypes.NewStringValue("no"),
+			}),
+			expectedResult: basetypes.NewStringValue(`'a: b': 'x: y'
+comment: '# not a comment'
+empty: ""
+multiline: |-
+    line 1
+    line 2
+number: "0123"
+quote: it's "quoted"
+space: ' padded '
+unicode: héllo ✓
+"yes": "no"
+`),
+		},
+		{
+			result:         basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			expectedResult: basetypes.NewStringValue("{}\n"),
+		},
+		// unknown value
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewStringUnknown(),
+		},
+		{
+			result:         basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewStringNull(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := yamlResult(test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalValidateUnresolved(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("2"),
+	}
+
+	var tests = []struct {
+		resultKeys  []basetypes.StringValue
+		maxUnknowns basetypes.Int64Value
+		expectedOk  bool
+	}{
+		// all resolved without a limit
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			maxUnknowns: basetypes.NewInt64Null(),
+			expectedOk:  true,
+		},
+		// missing without a limit
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+			},
+			maxUnknowns: basetypes.NewInt64Null(),
+			expectedOk:  false,
+		},
+		// missing at the limit
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+				basetypes.NewStringValue("d"),
+			},
+			maxUnknowns: basetypes.NewInt64Value(2),
+			expectedOk:  true,
+		},
+		// missing over the limit
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("c"),
+				basetypes.NewStringValue("d"),
+			},
+			maxUnknowns: basetypes.NewInt64Value(1),
+			expectedOk:  false,
+		},
+		// none allowed
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("c"),
+			},
+			maxUnknowns: basetypes.NewInt64Value(0),
+			expectedOk:  false,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.resultKeys, test.maxUnknowns)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualOk := validateUnresolved(resolve(keys, test.resultKeys, values), test.maxUnknowns, &diagnostics)
+
+			if actualOk != test.expectedOk || diagnostics.HasError() == actualOk {
+				t.Errorf("Got %t with %+v, wanted %t", actualOk, diagnostics, test.expectedOk)
+			}
+		})
+	}
+}
+
+func TestInternalKeyIndex(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys []basetypes.StringValue
+		expectedResult   basetypes.MapValue
+	}{
+		// all keys known, with a result key not in keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringValue("1"),
+			}),
+		},
+		// duplicate key uses the last position
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		// unknown key before and after known ones
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// result key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := keyIndex(test.keys, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalDefaultValues(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringUnknown(),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringNull(),
+		basetypes.NewStringNull(),
+		basetypes.NewStringNull(),
+	}
+
+	var tests = []struct {
+		defaults       basetypes.MapValue
+		expectedResult []basetypes.StringValue
+	}{
+		// explicit value, then provider default, then null
+		{
+			defaults: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("default"),
+				"b": basetypes.NewStringValue("default"),
+			}),
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("default"),
+				basetypes.NewStringNull(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+		// no defaults
+		{
+			defaults:       basetypes.NewMapNull(types.StringType),
+			expectedResult: values,
+		},
+		// defaults unknown
+		{
+			defaults: basetypes.NewMapUnknown(types.StringType),
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.defaults)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := defaultValues(keys, values, test.defaults)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalKeyPositions(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys []basetypes.StringValue
+		expectedResult   basetypes.ListValue
+	}{
+		// in result_keys order, with a result key not in keys
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+				basetypes.NewStringValue("a"),
+			},
+			expectedResult: basetypes.NewListValueMust(types.Int64Type, []attr.Value{
+				basetypes.NewInt64Value(1),
+				basetypes.NewInt64Null(),
+				basetypes.NewInt64Value(0),
+			}),
+		},
+		// unknown key and result key
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewListValueMust(types.Int64Type, []attr.Value{
+				basetypes.NewInt64Unknown(),
+				basetypes.NewInt64Value(2),
+				basetypes.NewInt64Unknown(),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := keyPositions(test.keys, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolvedFlags(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.MapValue
+	}{
+		// resolved, missing and value unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.BoolType, map[string]attr.Value{
+				"a": basetypes.NewBoolValue(true),
+				"b": basetypes.NewBoolUnknown(),
+				"c": basetypes.NewBoolValue(false),
+			}),
+		},
+		// key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.BoolType, map[string]attr.Value{
+				"a": basetypes.NewBoolValue(true),
+				"b": basetypes.NewBoolUnknown(),
+			}),
+		},
+		// result key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewMapUnknown(types.BoolType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolve(test.keys, test.resultKeys, test.values).resolvedFlags()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionInheritPrecedence(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+	}
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+	}
+	base := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("10"),
+		"b": basetypes.NewStringValue("20"),
+		"c": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		values         []basetypes.StringValue
+		expectedResult basetypes.MapValue
+	}{
+		// unknown and null values from keys still take precedence over base
+		{
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringNull(),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringNull(),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// an unknown value in base only affects its own key
+		{
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.values, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(keys, resultKeys, test.values)
+			res.inherit(base)
+			actualResult := res.result()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResultMismatches(t *testing.T) {
+	var tests = []struct {
+		result, expected basetypes.MapValue
+		expectedResult   []string
+	}{
+		// matching
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: nil,
+		},
+		// differing value and keys only on one side
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("10"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			expectedResult: []string{"a", "b", "c"},
+		},
+		// unknown entries are skipped
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: nil,
+		},
+		// unknown result
+		{
+			result: basetypes.NewMapUnknown(types.StringType),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: nil,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expected)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resultMismatches(test.result, test.expected)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalStableResult(t *testing.T) {
+	var tests = []struct {
+		preferred, result basetypes.MapValue
+		expectedResult    basetypes.MapValue
+	}{
+		// unknown entries take the known preferred value, other entries are kept
+		{
+			preferred: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"d": basetypes.NewStringValue("4"),
+			}),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// known preferred values are kept over new ones, as at apply
+		{
+			preferred: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("10"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		// no prior result
+		{
+			preferred: basetypes.NewMapNull(types.StringType),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+		},
+		// result unknown as a whole
+		{
+			preferred: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.preferred, test.result)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := stableResult(test.preferred, test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalFillUnknownValues(t *testing.T) {
+	var tests = []struct {
+		result, fallback basetypes.MapValue
+		expectedResult   basetypes.MapValue
+	}{
+		// unknown entries take the known fallback value, known entries are kept
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("20"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+			fallback: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("20"),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		// a key that is not in result is left out
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			fallback: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		// no prior result
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			fallback: basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.fallback)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := fillUnknownValues(test.result, test.fallback)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalStableResultKeys(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...interface{}) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+	read := func(configured map[string]tftypes.Value) mapModel {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			if value, ok := configured[name]; ok {
+				attributes[name] = value
+			} else {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+		}
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+		var model mapModel
+		if diagnostics := plan.Get(ctx, &model); diagnostics.HasError() {
+			t.Fatalf("Got errors %+v", diagnostics)
+		}
+		return model
+	}
+
+	var tests = []struct {
+		resultKeys     tftypes.Value
+		prior          basetypes.MapValue
+		expectedResult basetypes.MapValue
+	}{
+		// a key is added while a value becomes unknown
+		{
+			resultKeys: stringList("a", "b"),
+			prior: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+		},
+		// a key is removed while a value becomes unknown
+		{
+			resultKeys: stringList("a"),
+			prior: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.resultKeys, test.prior)
+
+		t.Run(testname, func(t *testing.T) {
+			model := read(map[string]tftypes.Value{
+				"keys":          stringList("a", "b"),
+				"result_keys":   test.resultKeys,
+				"stable_result": tftypes.NewValue(tftypes.Bool, true),
+				"values":        stringList(tftypes.UnknownValue, "2"),
+			})
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+
+			var diagnostics diag.Diagnostics
+			r.modify(ctx, model, test.prior, &diagnostics, &plan, false)
+
+			var planned types.Map
+			diagnostics.Append(plan.GetAttribute(ctx, path.Root("result"), &planned)...)
+
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			if !reflect.DeepEqual(test.expectedResult, planned) {
+				t.Errorf("Got %+v at plan, wanted %+v", planned, test.expectedResult)
+			}
+
+			// At apply the value is known, and the result must agree with the plan.
+			model = read(map[string]tftypes.Value{
+				"keys":          stringList("a", "b"),
+				"result_keys":   test.resultKeys,
+				"stable_result": tftypes.NewValue(tftypes.Bool, true),
+				"values":        stringList("10", "2"),
+			})
+			model.Result = planned
+			state := tfsdk.State{Schema: schemaResp.Schema}
+
+			r.modify(ctx, model, test.prior, &diagnostics, &state, true)
+
+			var applied types.Map
+			diagnostics.Append(state.GetAttribute(ctx, path.Root("result"), &applied)...)
+
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			if !reflect.DeepEqual(planned, applied) {
+				t.Errorf("Got %+v at apply, wanted %+v", applied, planned)
+			}
+		})
+	}
+}
+
+func TestInternalKeepLastGood(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+	configured := map[string]tftypes.Value{
+		"keep_last_good": tftypes.NewValue(tftypes.Bool, true),
+		"keys":           stringList("a", "b"),
+		"result_keys":    stringList("a", "c"),
+		"values":         stringList("10", "2"),
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := configured[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+	var model mapModel
+	diagnostics := plan.Get(ctx, &model)
+	if diagnostics.HasError() {
+		t.Fatalf("Got errors %+v", diagnostics)
+	}
+
+	prior := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+	})
+
+	var tests = []struct {
+		prior          basetypes.MapValue
+		expectedResult basetypes.MapValue
+		expectedError  bool
+	}{
+		// the prior result is kept
+		{
+			prior:          prior,
+			expectedResult: prior,
+		},
+		// nothing to keep
+		{
+			prior:         basetypes.NewMapNull(types.StringType),
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.prior)
+
+		t.Run(testname, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema}
+
+			var diagnostics diag.Diagnostics
+			r.modify(ctx, model, test.prior, &diagnostics, &state, true)
+
+			if diagnostics.HasError() != test.expectedError {
+				t.Fatalf("Got errors %+v, wanted errors %t", diagnostics, test.expectedError)
+			}
+
+			if test.expectedError {
+				return
+			}
+
+			var result types.Map
+			diagnostics.Append(state.GetAttribute(ctx, path.Root("result"), &result)...)
+
+			if !reflect.DeepEqual(test.expectedResult, result) {
+				t.Errorf("Got %+v, wanted %+v", result, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalNextVersion(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringNull(),
+	})
+	changed := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringValue(""),
+	})
+
+	var tests = []struct {
+		result, prior  basetypes.MapValue
+		priorVersion   basetypes.Int64Value
+		expectedResult basetypes.Int64Value
+	}{
+		// created
+		{
+			result:         result,
+			prior:          basetypes.NewMapNull(types.StringType),
+			priorVersion:   basetypes.NewInt64Null(),
+			expectedResult: basetypes.NewInt64Value(1),
+		},
+		// unchanged
+		{
+			result:         result,
+			prior:          result,
+			priorVersion:   basetypes.NewInt64Value(3),
+			expectedResult: basetypes.NewInt64Value(3),
+		},
+		// a null value changed to empty
+		{
+			result:         changed,
+			prior:          result,
+			priorVersion:   basetypes.NewInt64Value(3),
+			expectedResult: basetypes.NewInt64Value(4),
+		},
+		// unknown value
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			prior:          result,
+			priorVersion:   basetypes.NewInt64Value(3),
+			expectedResult: basetypes.NewInt64Unknown(),
+		},
+		// unknown result
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			prior:          result,
+			priorVersion:   basetypes.NewInt64Value(3),
+			expectedResult: basetypes.NewInt64Unknown(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.result, test.prior, test.priorVersion)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := nextVersion(resultHash(test.result), resultHash(test.prior), test.priorVersion)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionFallback(t *testing.T) {
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+		basetypes.NewStringValue("d"),
+	}
+	source := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("fallback"),
+		"b": basetypes.NewStringValue("fallback"),
+	})
+	defaults := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"b": basetypes.NewStringValue("default"),
+		"c": basetypes.NewStringValue("default"),
+	})
+
+	var tests = []struct {
+		keys, values   []basetypes.StringValue
+		source         basetypes.MapValue
+		defaults       basetypes.MapValue
+		expectedResult basetypes.MapValue
+	}{
+		// primary, then fallback, then default, then null
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			source:   source,
+			defaults: defaults,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("fallback"),
+				"c": basetypes.NewStringValue("default"),
+				"d": basetypes.NewStringNull(),
+			}),
+		},
+		// an unknown primary value wins over the fallback
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			source:   source,
+			defaults: basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("fallback"),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringNull(),
+			}),
+		},
+		// an unknown key may override any fallback
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			source:   source,
+			defaults: defaults,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringUnknown(),
+				"d": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown fallback source
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			source:         basetypes.NewMapUnknown(types.StringType),
+			defaults:       defaults,
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v,%+v", test.keys, test.values, test.source, test.defaults)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(test.keys, resultKeys, test.values)
+			res.fallback(test.source, test.defaults)
+			actualResult := res.result()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionCount(t *testing.T) {
+	count := func(missing, resolved, total, unknown int64) basetypes.ObjectValue {
+		return basetypes.NewObjectValueMust(resultCountType.AttrTypes, map[string]attr.Value{
+			"missing":  basetypes.NewInt64Value(missing),
+			"resolved": basetypes.NewInt64Value(resolved),
+			"total":    basetypes.NewInt64Value(total),
+			"unknown":  basetypes.NewInt64Value(unknown),
+		})
+	}
+
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           basetypes.ObjectValue
+	}{
+		// resolved, value unknown and missing, with a duplicate result key
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: count(1, 1, 3, 1),
+		},
+		// key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: count(0, 0, 1, 1),
+		},
+		// result key unknown
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+			},
+			expectedResult: basetypes.NewObjectUnknown(resultCountType.AttrTypes),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resolve(test.keys, test.resultKeys, test.values).count()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalChangedKeys(t *testing.T) {
+	prior := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringValue("2"),
+		"c": basetypes.NewStringValue("3"),
+	})
+
+	var tests = []struct {
+		prior, planned, result basetypes.MapValue
+		expectedResult         basetypes.ListValue
+	}{
+		// addition, removal and value change
+		{
+			prior:   prior,
+			planned: basetypes.NewMapNull(types.StringType),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("20"),
+				"d": basetypes.NewStringValue("4"),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("c"),
+				basetypes.NewStringValue("d"),
+			}),
+		},
+		// unknown value at plan
+		{
+			prior:   prior,
+			planned: basetypes.NewMapNull(types.StringType),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+		},
+		// value unknown at plan but unchanged at apply
+		{
+			prior: prior,
+			planned: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+				"b": basetypes.NewStringValue("2"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			result: prior,
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+		},
+		// created
+		{
+			prior:   basetypes.NewMapNull(types.StringType),
+			planned: basetypes.NewMapNull(types.StringType),
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: basetypes.NewListValueMust(types.StringType, []attr.Value{
+				basetypes.NewStringValue("a"),
+			}),
+		},
+		// result unknown
+		{
+			prior:          prior,
+			planned:        basetypes.NewMapNull(types.StringType),
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewListUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.prior, test.planned, test.result)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := changedKeys(test.prior, test.planned, test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalCompileRegex(t *testing.T) {
+	var tests = []struct {
+		pattern       string
+		expectedError bool
+	}{
+		{pattern: "^(a+)+$"},
+		{pattern: strings.Repeat("a", maxRegexLength)},
+		{pattern: strings.Repeat("a", maxRegexLength+1), expectedError: true},
+		{pattern: "(", expectedError: true},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%.20s,%d", test.pattern, len(test.pattern))
+
+		t.Run(testname, func(t *testing.T) {
+			_, err := compileRegex(test.pattern)
+
+			if (err != nil) != test.expectedError {
+				t.Errorf("Got error %v, wanted error %t", err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestInternalCompileRegexHugeInput(t *testing.T) {
+	// Catastrophic for backtracking engines on an almost matching input, but linear here.
+	regex, err := compileRegex("^(a+)+$")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []basetypes.StringValue{basetypes.NewStringValue(strings.Repeat("a", 1<<20) + "b")}
+	values := []basetypes.StringValue{basetypes.NewStringNull()}
+
+	start := time.Now()
+	derived := valuesFromKeys(keys, values, regex, "${0}")
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Took %s, wanted it to complete quickly", elapsed)
+	}
+
+	if !derived[0].IsNull() {
+		t.Errorf("Got %+v, wanted null", derived[0])
+	}
+}
+
+func TestInternalTransformValues(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("prefix-value-suffix"),
+		"b": basetypes.NewStringValue("value"),
+		"c": basetypes.NewStringNull(),
+		"d": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		result         basetypes.MapValue
+		transform      func(string) string
+		expectedResult basetypes.MapValue
+	}{
+		// trim prefix
+		{
+			result: result,
+			transform: func(value string) string {
+				return strings.TrimPrefix(value, "prefix-")
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("value-suffix"),
+				"b": basetypes.NewStringValue("value"),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringUnknown(),
+			}),
+		},
+		// trim suffix
+		{
+			result: result,
+			transform: func(value string) string {
+				return strings.TrimSuffix(value, "-suffix")
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("prefix-value"),
+				"b": basetypes.NewStringValue("value"),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown result
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			transform:      strings.ToUpper,
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := transformValues(test.result, test.transform)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalReplacements(t *testing.T) {
+	rule := func(from, to basetypes.StringValue) attr.Value {
+		return basetypes.NewObjectValueMust(replacementType.AttrTypes, map[string]attr.Value{
+			"from": from,
+			"to":   to,
+		})
+	}
+
+	var tests = []struct {
+		rules          basetypes.ListValue
+		value          string
+		expectedResult string
+		expectedOk     bool
+	}{
+		// applied in order
+		{
+			rules: basetypes.NewListValueMust(replacementType, []attr.Value{
+				rule(basetypes.NewStringValue("__ENV__"), basetypes.NewStringValue("prod")),
+				rule(basetypes.NewStringValue("prod"), basetypes.NewStringValue("production")),
+			}),
+			value:          "__ENV__/__ENV__",
+			expectedResult: "production/production",
+			expectedOk:     true,
+		},
+		// empty from is skipped
+		{
+			rules: basetypes.NewListValueMust(replacementType, []attr.Value{
+				rule(basetypes.NewStringValue(""), basetypes.NewStringValue("x")),
+			}),
+			value:          "abc",
+			expectedResult: "abc",
+			expectedOk:     true,
+		},
+		// unknown rule
+		{
+			rules: basetypes.NewListValueMust(replacementType, []attr.Value{
+				rule(basetypes.NewStringValue("a"), basetypes.NewStringUnknown()),
+			}),
+			value:      "abc",
+			expectedOk: false,
+		},
+		// unknown rules
+		{
+			rules:      basetypes.NewListUnknown(replacementType),
+			value:      "abc",
+			expectedOk: false,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.rules, test.value)
+
+		t.Run(testname, func(t *testing.T) {
+			replace, ok := replacements(test.rules)
+
+			if ok != test.expectedOk {
+				t.Fatalf("Got %+v, wanted %+v", ok, test.expectedOk)
+			}
+
+			actualResult := test.value
+			if replace != nil {
+				actualResult = replace(test.value)
+			}
+
+			if ok && actualResult != test.expectedResult {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalApplyTransforms(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue(" Value "),
+		"b": basetypes.NewStringValue("Value"),
+		"c": basetypes.NewStringNull(),
+		"d": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		result, transforms basetypes.MapValue
+		expectedResult     basetypes.MapValue
+	}{
+		// per key transforms, other keys pass through
+		{
+			result: result,
+			transforms: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("trim"),
+				"b": basetypes.NewStringValue("base64encode"),
+				"c": basetypes.NewStringValue("upper"),
+				"d": basetypes.NewStringValue("lower"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("Value"),
+				"b": basetypes.NewStringValue("VmFsdWU="),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown transform
+		{
+			result: result,
+			transforms: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue(" Value "),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+				"d": basetypes.NewStringUnknown(),
+			}),
+		},
+		// unknown transforms
+		{
+			result:         result,
+			transforms:     basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+		// no transforms
+		{
+			result:         result,
+			transforms:     basetypes.NewMapNull(types.StringType),
+			expectedResult: result,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.transforms)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := applyTransforms(test.result, test.transforms)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolutionRename(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a-1"),
+		basetypes.NewStringValue("a_2"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("2"),
+	}
+
+	var tests = []struct {
+		transform          func(string) string
+		expectedResult     basetypes.MapValue
+		expectedCollisions []string
+	}{
+		// renamed
+		{
+			transform: strings.ToUpper,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"A-1": basetypes.NewStringValue("1"),
+				"A_2": basetypes.NewStringValue("2"),
+			}),
+		},
+		// collision leaves the keys as they are
+		{
+			transform: func(key string) string {
+				return "a"
+			},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a-1": basetypes.NewStringValue("1"),
+				"a_2": basetypes.NewStringValue("2"),
+			}),
+			expectedCollisions: []string{`"a-1" and "a_2" both become "a".`},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.expectedResult)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(keys, keys, values)
+			actualCollisions := res.rename(test.transform)
+			actualResult := res.result()
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+
+			if !reflect.DeepEqual(test.expectedCollisions, actualCollisions) {
+				t.Errorf("Got %+v, wanted %+v", actualCollisions, test.expectedCollisions)
+			}
+		})
+	}
+}
+
+func TestInternalValidateSize(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		result           basetypes.MapValue
+		minKeys, maxKeys basetypes.Int64Value
+		expectedOk       bool
+	}{
+		// within range
+		{
+			result:     result,
+			minKeys:    basetypes.NewInt64Value(2),
+			maxKeys:    basetypes.NewInt64Value(2),
+			expectedOk: true,
+		},
+		// below min
+		{
+			result:     result,
+			minKeys:    basetypes.NewInt64Value(3),
+			maxKeys:    basetypes.NewInt64Null(),
+			expectedOk: false,
+		},
+		// above max
+		{
+			result:     result,
+			minKeys:    basetypes.NewInt64Null(),
+			maxKeys:    basetypes.NewInt64Value(1),
+			expectedOk: false,
+		},
+		// unknown bound
+		{
+			result:     result,
+			minKeys:    basetypes.NewInt64Unknown(),
+			maxKeys:    basetypes.NewInt64Unknown(),
+			expectedOk: true,
+		},
+		// unknown result
+		{
+			result:     basetypes.NewMapUnknown(types.StringType),
+			minKeys:    basetypes.NewInt64Value(3),
+			maxKeys:    basetypes.NewInt64Null(),
+			expectedOk: true,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.result, test.minKeys, test.maxKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualOk := validateSize(test.result, test.minKeys, test.maxKeys, &diagnostics)
+
+			if actualOk != test.expectedOk || diagnostics.HasError() == actualOk {
+				t.Errorf("Got %t with %+v, wanted %t", actualOk, diagnostics, test.expectedOk)
+			}
+		})
+	}
+}
+
+func TestInternalResultJSONPath(t *testing.T) {
+	var tests = []struct {
+		keys, resultKeys, values []basetypes.StringValue
+		expectedResult           string
+	}{
+		// distinct result keys in order, whether or not they resolve
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("b"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("2"),
+			},
+			expectedResult: "$.b;$.a",
+		},
+		// bracket notation
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("it's"),
+				basetypes.NewStringValue("a-b"),
+				basetypes.NewStringValue("1st"),
+			},
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("it's"),
+				basetypes.NewStringValue("a-b"),
+				basetypes.NewStringValue("1st"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringValue("3"),
+			},
+			expectedResult: `$['it\'s'];$['a-b'];$['1st']`,
+		},
+		// no result keys
+		{
+			expectedResult: "",
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := resultJSONPath(resolve(test.keys, test.resultKeys, test.values))
+
+			if test.expectedResult != actualResult {
+				t.Errorf("Got %s, wanted %s", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalUnmatchedElements(t *testing.T) {
+	regex := regexp.MustCompile(`^[a-z]+$`)
+
+	var tests = []struct {
+		elements       []basetypes.StringValue
+		expectedResult []string
+	}{
+		// unmatched elements in order
+		{
+			elements: []basetypes.StringValue{
+				basetypes.NewStringValue("B"),
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a-1"),
+			},
+			expectedResult: []string{`"B"`, `"a-1"`},
+		},
+		// unknown elements are not matched
+		{
+			elements: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedResult: nil,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.elements)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := unmatchedElements(test.elements, regex)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalUnresolvedAfterDeadline(t *testing.T) {
+	deadline := basetypes.NewStringValue("2024-06-01T12:00:00Z")
+	unresolved := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"b": basetypes.NewStringValue("missing"),
+	})
+
+	var tests = []struct {
+		deadline      basetypes.StringValue
+		reasons       basetypes.MapValue
+		now           time.Time
+		expectedError bool
+	}{
+		// before the deadline
+		{
+			deadline:      deadline,
+			reasons:       unresolved,
+			now:           time.Date(2024, 6, 1, 11, 59, 59, 0, time.UTC),
+			expectedError: false,
+		},
+		// after the deadline
+		{
+			deadline:      deadline,
+			reasons:       unresolved,
+			now:           time.Date(2024, 6, 1, 12, 0, 1, 0, time.UTC),
+			expectedError: true,
+		},
+		// after the deadline, everything resolved
+		{
+			deadline:      deadline,
+			reasons:       basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+			now:           time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+			expectedError: false,
+		},
+		// no deadline
+		{
+			deadline:      basetypes.NewStringNull(),
+			reasons:       unresolved,
+			now:           time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+			expectedError: false,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.deadline, test.reasons, test.now)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			unresolvedAfterDeadline(test.deadline, test.reasons, test.now, &diagnostics)
+
+			if diagnostics.HasError() != test.expectedError {
+				t.Errorf("Got errors %+v, wanted errors %t", diagnostics, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestInternalValidateMatching(t *testing.T) {
+	elements := []basetypes.StringValue{
+		basetypes.NewStringValue("arn:aws:s3:::bucket"),
+		basetypes.NewStringValue("bucket"),
+		basetypes.NewStringUnknown(),
+	}
+
+	var tests = []struct {
+		pattern, message basetypes.StringValue
+		expectedSummary  string
+	}{
+		// default message
+		{
+			pattern:         basetypes.NewStringValue("^arn:"),
+			message:         basetypes.NewStringNull(),
+			expectedSummary: "Invalid value",
+		},
+		// custom message
+		{
+			pattern:         basetypes.NewStringValue("^arn:"),
+			message:         basetypes.NewStringValue("Values must be ARNs"),
+			expectedSummary: "Values must be ARNs",
+		},
+		// every known element matches
+		{
+			pattern: basetypes.NewStringValue("bucket$"),
+			message: basetypes.NewStringNull(),
+		},
+		// pattern not known yet
+		{
+			pattern: basetypes.NewStringUnknown(),
+			message: basetypes.NewStringNull(),
+		},
+		// invalid pattern
+		{
+			pattern:         basetypes.NewStringValue("("),
+			message:         basetypes.NewStringNull(),
+			expectedSummary: "Invalid regular expression",
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.pattern, test.message)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			validateMatching("values", elements, "value_validation_regex", test.pattern, test.message, "Invalid value", &diagnostics)
+
+			actualSummary := ""
+			if diagnostics.HasError() {
+				actualSummary = diagnostics.Errors()[0].Summary()
+			}
+
+			if actualSummary != test.expectedSummary {
+				t.Errorf("Got %+v, wanted %q", diagnostics, test.expectedSummary)
+			}
+		})
+	}
+}
+
+func TestInternalKeysFromSource(t *testing.T) {
+	var tests = []struct {
+		source         basetypes.MapValue
+		valueCount     int
+		expectedResult []basetypes.StringValue
+	}{
+		// values in order of the sorted keys, including unknown ones
+		{
+			source: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"2": basetypes.NewStringValue("c"),
+				"0": basetypes.NewStringValue("a"),
+				"1": basetypes.NewStringUnknown(),
+			}),
+			valueCount: 3,
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringValue("c"),
+			},
+		},
+		// unknown source
+		{
+			source:     basetypes.NewMapUnknown(types.StringType),
+			valueCount: 2,
+			expectedResult: []basetypes.StringValue{
+				basetypes.NewStringUnknown(),
+				basetypes.NewStringUnknown(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.source, test.valueCount)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := keysFromSource(test.source, test.valueCount)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResolveKeySourceUnknownEntry(t *testing.T) {
+	source := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"0": basetypes.NewStringValue("a"),
+		"1": basetypes.NewStringUnknown(),
+	})
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("2"),
+	}
+
+	keys := keysFromSource(source, len(values))
+
+	// a resolves, while b may be the unknown key
+	actualResult := resolve(keys, []basetypes.StringValue{basetypes.NewStringValue("a")}, values).result()
+	expectedResult := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+	})
+
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		t.Errorf("Got %+v, wanted %+v", actualResult, expectedResult)
+	}
+
+	actualResult = resolve(keys, []basetypes.StringValue{basetypes.NewStringValue("b")}, values).result()
+	expectedResult = basetypes.NewMapUnknown(types.StringType)
+
+	if !reflect.DeepEqual(expectedResult, actualResult) {
+		t.Errorf("Got %+v, wanted %+v", actualResult, expectedResult)
+	}
+}
+
+func TestInternalValidateMatchingResultKeys(t *testing.T) {
+	pattern := basetypes.NewStringValue("^[A-Z_]+$")
+
+	var tests = []struct {
+		resultKeys    []basetypes.StringValue
+		expectedError bool
+	}{
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("DB_HOST"),
+				basetypes.NewStringUnknown(),
+			},
+			expectedError: false,
+		},
+		{
+			resultKeys: []basetypes.StringValue{
+				basetypes.NewStringValue("DB_HOST"),
+				basetypes.NewStringValue("db-port"),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			validateMatching("result_keys", test.resultKeys, "result_key_validation_regex", pattern, basetypes.NewStringNull(), "Invalid result key", &diagnostics)
+
+			if diagnostics.HasError() != test.expectedError {
+				t.Errorf("Got errors %+v, wanted errors %t", diagnostics, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestInternalShadowedKeys(t *testing.T) {
+	var tests = []struct {
+		keys, values   []basetypes.StringValue
+		base           basetypes.MapValue
+		expectedResult []string
+	}{
+		// later duplicate key with a different value
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("2"),
+			},
+			base:           basetypes.NewMapNull(types.StringType),
+			expectedResult: []string{`"a" is "1" in keys but "2" later in keys.`},
+		},
+		// same value is not shadowing
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("a"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("1"),
+				basetypes.NewStringValue("1"),
+			},
+			base: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: nil,
+		},
+		// keys shadow inherit_from, unknown values are not compared
+		{
+			keys: []basetypes.StringValue{
+				basetypes.NewStringValue("a"),
+				basetypes.NewStringValue("b"),
+			},
+			values: []basetypes.StringValue{
+				basetypes.NewStringValue("2"),
+				basetypes.NewStringUnknown(),
+			},
+			base: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("1"),
+			}),
+			expectedResult: []string{`"a" is "1" in inherit_from but "2" later in keys.`},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.values, test.base)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := shadowedKeys(test.keys, test.values, test.base)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalWarnOnShadow(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	var tests = []struct {
+		warnOnShadow     bool
+		values           tftypes.Value
+		expectedWarnings int
+	}{
+		{
+			warnOnShadow:     true,
+			values:           stringList("1", "2"),
+			expectedWarnings: 1,
+		},
+		// values do not differ
+		{
+			warnOnShadow:     true,
+			values:           stringList("1", "1"),
+			expectedWarnings: 0,
+		},
+		// not asked for
+		{
+			warnOnShadow:     false,
+			values:           stringList("1", "2"),
+			expectedWarnings: 0,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.warnOnShadow, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			configured := map[string]tftypes.Value{
+				"keys":              stringList("a", "a"),
+				"result_keys":       stringList("a"),
+				"result_keys_dedup": tftypes.NewValue(tftypes.Bool, true),
+				"values":            test.values,
+				"warn_on_shadow":    tftypes.NewValue(tftypes.Bool, test.warnOnShadow),
+			}
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := configured[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+			var model mapModel
+			diagnostics := plan.Get(ctx, &model)
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			r.modify(ctx, model, basetypes.NewMapNull(types.StringType), &diagnostics, &plan, false)
+
+			if diagnostics.HasError() || diagnostics.WarningsCount() != test.expectedWarnings {
+				t.Errorf("Got %+v, wanted %d warnings", diagnostics, test.expectedWarnings)
+			}
+		})
+	}
+}
+
+func TestInternalNullSafeResult(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		expectedResult basetypes.MapValue
+	}{
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringNull(),
+				"c": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue(""),
+				"c": basetypes.NewStringUnknown(),
+			}),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+		{
+			result:         basetypes.NewMapNull(types.StringType),
+			expectedResult: basetypes.NewMapNull(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.result)
+
+		t.Run(testname, func(t *testing.T) {
+			actualResult := nullSafeResult(test.result)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalResultWithoutDefaults(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+	}
+	resultKeys := []basetypes.StringValue{
+		basetypes.NewStringValue("a"),
+		basetypes.NewStringValue("b"),
+		basetypes.NewStringValue("c"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+	}
+	overrides := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"c": basetypes.NewStringValue("3"),
+	})
+
+	var tests = []struct {
+		defaultValue           basetypes.StringValue
+		expectedResult         basetypes.MapValue
+		expectedWithoutDefault basetypes.MapValue
+	}{
+		// defaulted entries are left out, overridden ones are not
+		{
+			defaultValue: basetypes.NewStringValue("x"),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("x"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			expectedWithoutDefault: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// unknown default
+		{
+			defaultValue: basetypes.NewStringUnknown(),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringValue("3"),
+			}),
+			expectedWithoutDefault: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringValue("3"),
+			}),
+		},
+		// no default
+		{
+			defaultValue:           basetypes.NewStringNull(),
+			expectedResult:         basetypes.NewMapNull(types.StringType),
+			expectedWithoutDefault: basetypes.NewMapNull(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.defaultValue)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(keys, resultKeys, values)
+			res.defaultTo(test.defaultValue)
+			res.overwrite(overrides)
+
+			actualResult := res.result()
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+
+			actualWithoutDefault := resultWithoutDefaults(actualResult, res, test.defaultValue)
+			if !reflect.DeepEqual(test.expectedWithoutDefault, actualWithoutDefault) {
+				t.Errorf("Got %+v, wanted %+v", actualWithoutDefault, test.expectedWithoutDefault)
+			}
+		})
+	}
+}
+
+func TestInternalCoerceKeys(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		expected   []basetypes.StringValue
+	}{
+		// numbers match by canonical form
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("1e3"), basetypes.NewStringValue("0.5")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("1.0"), basetypes.NewStringValue("1000"), basetypes.NewStringValue(".50")},
+			expected:   []basetypes.StringValue{basetypes.NewStringValue("1.0"), basetypes.NewStringValue("1000"), basetypes.NewStringValue(".50")},
+		},
+		// the first matching result key is used
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("1")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("01"), basetypes.NewStringValue("1.0")},
+			expected:   []basetypes.StringValue{basetypes.NewStringValue("01")},
+		},
+		// keys that are not numbers or have no match are left alone
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("2"), basetypes.NewStringValue("Inf"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("3"), basetypes.NewStringValue("+Inf"), basetypes.NewStringUnknown()},
+			expected:   []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("2"), basetypes.NewStringValue("Inf"), basetypes.NewStringUnknown()},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := coerceKeys(test.keys, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalDefaultedKeys(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		expected   basetypes.ListValue
+	}{
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b"), basetypes.NewStringValue("a")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("b")}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+		},
+		// the unknown key may be the defaulted one
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringUnknown()},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			values := make([]basetypes.StringValue, len(test.keys))
+			for i := range values {
+				values[i] = basetypes.NewStringValue("1")
+			}
+
+			res := resolve(test.keys, test.resultKeys, values)
+			res.defaultTo(basetypes.NewStringValue("x"))
+
+			actual := res.defaultedKeys()
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalAllKeys(t *testing.T) {
+	var tests = []struct {
+		keys     []basetypes.StringValue
+		expected []basetypes.StringValue
+	}{
+		{
+			keys:     []basetypes.StringValue{basetypes.NewStringValue("b"), basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			expected: []basetypes.StringValue{basetypes.NewStringValue("b"), basetypes.NewStringValue("a")},
+		},
+		{
+			keys:     []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringNull(), basetypes.NewStringUnknown()},
+			expected: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+		},
+		{
+			keys:     []basetypes.StringValue{},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.keys)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := allKeys(test.keys)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalResultSizeMatches(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		values     []basetypes.StringValue
+		partial    bool
+		expected   basetypes.BoolValue
+	}{
+		// unknown values still count
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b"), basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringUnknown()},
+			expected:   basetypes.NewBoolValue(true),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			partial:    true,
+			expected:   basetypes.NewBoolValue(false),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewBoolValue(false),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			expected:   basetypes.NewBoolUnknown(),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v,%t", test.keys, test.resultKeys, test.values, test.partial)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(test.keys, test.resultKeys, test.values)
+
+			result := res.result()
+			if test.partial {
+				result = res.partialResult()
+			}
+
+			actual := resultSizeMatches(result, res)
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalSafeResult(t *testing.T) {
+	result := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"a": basetypes.NewStringValue("1"),
+		"b": basetypes.NewStringUnknown(),
+		"c": basetypes.NewStringNull(),
+	})
+
+	var tests = []struct {
+		result      basetypes.MapValue
+		placeholder basetypes.StringValue
+		expected    basetypes.MapValue
+	}{
+		{
+			result:      result,
+			placeholder: basetypes.NewStringValue("?"),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("?"),
+				"c": basetypes.NewStringNull(),
+			}),
+		},
+		{
+			result:      result,
+			placeholder: basetypes.NewStringUnknown(),
+			expected:    basetypes.NewMapUnknown(types.StringType),
+		},
+		// nothing to replace
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+			placeholder: basetypes.NewStringUnknown(),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+			}),
+		},
+		{
+			result:      basetypes.NewMapUnknown(types.StringType),
+			placeholder: basetypes.NewStringValue("?"),
+			expected:    basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.placeholder)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := safeResult(test.result, test.placeholder)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalFoundResultKeys(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		expected   basetypes.ListValue
+	}{
+		// unknown keys do not make found result keys unknown
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown(), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b"), basetypes.NewStringValue("c"), basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("b"), basetypes.NewStringValue("a")}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("c")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := foundResultKeys(test.keys, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalDurationValues(t *testing.T) {
+	var tests = []struct {
+		result         basetypes.MapValue
+		normalize      bool
+		expectedResult basetypes.MapValue
+		expectedOk     bool
+	}{
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("90s"),
+				"b": basetypes.NewStringNull(),
+				"c": basetypes.NewStringUnknown(),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("90s"),
+				"b": basetypes.NewStringNull(),
+				"c": basetypes.NewStringUnknown(),
+			}),
+			expectedOk: true,
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("90s"),
+				"b": basetypes.NewStringValue("1.5h"),
+			}),
+			normalize: true,
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1m30s"),
+				"b": basetypes.NewStringValue("1h30m0s"),
+			}),
+			expectedOk: true,
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("10"),
+			}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("10"),
+			}),
+		},
+		{
+			result:         basetypes.NewMapUnknown(types.StringType),
+			normalize:      true,
+			expectedResult: basetypes.NewMapUnknown(types.StringType),
+			expectedOk:     true,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%t", test.result, test.normalize)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualResult, actualOk := durationValues(test.result, test.normalize, &diagnostics)
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) || actualOk != test.expectedOk {
+				t.Errorf("Got %+v %t, wanted %+v %t", actualResult, actualOk, test.expectedResult, test.expectedOk)
+			}
+
+			if diagnostics.HasError() == test.expectedOk {
+				t.Errorf("Got %+v, wanted errors %t", diagnostics, !test.expectedOk)
+			}
+		})
+	}
+}
+
+func TestInternalNotFoundResultKeys(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		expected   basetypes.ListValue
+	}{
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("c"), basetypes.NewStringValue("a"), basetypes.NewStringValue("c")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("c")}),
+		},
+		// an unknown key that nothing could turn out to be
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			expected:   basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+		},
+		// the unknown key may be the missing result key
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("b")},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringUnknown()},
+			expected:   basetypes.NewListUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.keys, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := notFoundResultKeys(test.keys, test.resultKeys)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalImportedMap(t *testing.T) {
+	var tests = []struct {
+		id             string
+		expectedKeys   basetypes.ListValue
+		expectedValues basetypes.ListValue
+		expectedResult basetypes.MapValue
+		expectedError  bool
+	}{
+		{
+			id:             `{"b": "2", "a": null}`,
+			expectedKeys:   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")}),
+			expectedValues: basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringNull(), basetypes.NewStringValue("2")}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringNull(),
+				"b": basetypes.NewStringValue("2"),
+			}),
+		},
+		{
+			id:             `{}`,
+			expectedKeys:   basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+			expectedValues: basetypes.NewListValueMust(types.StringType, []attr.Value{}),
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			id:            `{"a": 1}`,
+			expectedError: true,
+		},
+		{
+			id:            `null`,
+			expectedError: true,
+		},
+		{
+			id:            `-`,
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		testname := test.id
+
+		t.Run(testname, func(t *testing.T) {
+			actualKeys, actualValues, actualResult, err := importedMap(test.id)
+
+			if (err != nil) != test.expectedError {
+				t.Fatalf("Got error %+v, wanted error %t", err, test.expectedError)
+			}
+
+			if test.expectedError {
+				return
+			}
+
+			if !reflect.DeepEqual(test.expectedKeys, actualKeys) {
+				t.Errorf("Got %+v, wanted %+v", actualKeys, test.expectedKeys)
+			}
+
+			if !reflect.DeepEqual(test.expectedValues, actualValues) {
+				t.Errorf("Got %+v, wanted %+v", actualValues, test.expectedValues)
+			}
+
+			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
+			}
+		})
+	}
+}
+
+func TestInternalValidateForbidden(t *testing.T) {
+	forbidden := basetypes.NewSetValueMust(types.StringType, []attr.Value{
+		basetypes.NewStringValue("CHANGEME"),
+		basetypes.NewStringUnknown(),
+	})
+
+	var tests = []struct {
+		keys      []basetypes.StringValue
+		values    []basetypes.StringValue
+		forbidden basetypes.SetValue
+		expected  diag.Diagnostics
+	}{
+		{
+			keys:      []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:    []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("CHANGEME")},
+			forbidden: forbidden,
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("values").AtListIndex(1), "Value is forbidden", `The value of key "b" is "CHANGEME", which is one of forbidden_values.`),
 			},
-			expectedResult: basetypes.NewMapUnknown(types.StringType),
+		},
+		// unknown and null values are exempt
+		{
+			keys:      []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b")},
+			values:    []basetypes.StringValue{basetypes.NewStringUnknown(), basetypes.NewStringNull()},
+			forbidden: forbidden,
+		},
+		{
+			keys:      []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:    []basetypes.StringValue{basetypes.NewStringValue("CHANGEME")},
+			forbidden: basetypes.NewSetUnknown(types.StringType),
+		},
+		{
+			keys:      []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:    []basetypes.StringValue{basetypes.NewStringValue("CHANGEME")},
+			forbidden: basetypes.NewSetNull(types.StringType),
 		},
 	}
 
 	for _, test := range tests {
-		testname := fmt.Sprintf("%+v,%+v,%+v,%+v", test.keys, test.resultKeys, test.values, test.expectedResult)
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.values, test.forbidden)
 
 		t.Run(testname, func(t *testing.T) {
-			actualResult := resolveMap(test.keys, test.resultKeys, test.values)
+			var actual diag.Diagnostics
+			validateForbidden(test.keys, test.values, test.forbidden, &actual)
 
-			if !reflect.DeepEqual(test.expectedResult, actualResult) {
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalComplement(t *testing.T) {
+	var tests = []struct {
+		keys       []basetypes.StringValue
+		resultKeys []basetypes.StringValue
+		values     []basetypes.StringValue
+		expected   basetypes.MapValue
+	}{
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("b"), basetypes.NewStringValue("b")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringValue("c")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2"), basetypes.NewStringUnknown()},
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"b": basetypes.NewStringUnknown(),
+			}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a"), basetypes.NewStringUnknown()},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("a")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			expected:   basetypes.NewMapUnknown(types.StringType),
+		},
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("a")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringUnknown()},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expected:   basetypes.NewMapUnknown(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.values)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := complement(test.keys, test.resultKeys, test.values)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalResultByGroup(t *testing.T) {
+	keys := []basetypes.StringValue{
+		basetypes.NewStringValue("A"),
+		basetypes.NewStringValue("B"),
+	}
+	values := []basetypes.StringValue{
+		basetypes.NewStringValue("1"),
+		basetypes.NewStringValue("2"),
+	}
+	groupType := types.ListType{ElemType: types.StringType}
+	mapType := types.MapType{ElemType: types.StringType}
+
+	var tests = []struct {
+		groups     basetypes.MapValue
+		transforms []func(string) string
+		expected   basetypes.MapValue
+	}{
+		{
+			groups: basetypes.NewMapValueMust(groupType, map[string]attr.Value{
+				"found":   basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("A")}),
+				"missing": basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("C")}),
+				"null":    basetypes.NewListNull(types.StringType),
+				"unknown": basetypes.NewListUnknown(types.StringType),
+			}),
+			expected: basetypes.NewMapValueMust(mapType, map[string]attr.Value{
+				"found": basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+					"A": basetypes.NewStringValue("1"),
+				}),
+				"missing": basetypes.NewMapNull(types.StringType),
+				"null":    basetypes.NewMapNull(types.StringType),
+				"unknown": basetypes.NewMapUnknown(types.StringType),
+			}),
+		},
+		// group keys are normalized like the keys
+		{
+			groups: basetypes.NewMapValueMust(groupType, map[string]attr.Value{
+				"all": basetypes.NewListValueMust(types.StringType, []attr.Value{basetypes.NewStringValue("B"), basetypes.NewStringValue("a")}),
+			}),
+			transforms: []func(string) string{strings.ToUpper},
+			expected: basetypes.NewMapValueMust(mapType, map[string]attr.Value{
+				"all": basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+					"A": basetypes.NewStringValue("1"),
+					"B": basetypes.NewStringValue("2"),
+				}),
+			}),
+		},
+		{
+			groups:   basetypes.NewMapNull(groupType),
+			expected: basetypes.NewMapNull(mapType),
+		},
+		{
+			groups:   basetypes.NewMapUnknown(groupType),
+			expected: basetypes.NewMapUnknown(mapType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.groups)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actual := resultByGroup(keys, values, test.groups, test.transforms, &diagnostics)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalStripResultKeyPrefix(t *testing.T) {
+	var tests = []struct {
+		resultKeys         []basetypes.StringValue
+		expectedResult     basetypes.MapValue
+		expectedCollisions []string
+	}{
+		{
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("app_a"), basetypes.NewStringValue("b")},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("3"),
+			}),
+		},
+		{
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("app_a"), basetypes.NewStringValue("a")},
+			expectedResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"app_a": basetypes.NewStringValue("1"),
+				"a":     basetypes.NewStringValue("2"),
+			}),
+			expectedCollisions: []string{`"app_a" and "a" both become "a".`},
+		},
+	}
+
+	keys := []basetypes.StringValue{basetypes.NewStringValue("app_a"), basetypes.NewStringValue("a"), basetypes.NewStringValue("b")}
+	values := []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2"), basetypes.NewStringValue("3")}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			res := resolve(keys, test.resultKeys, values)
+			actualCollisions := res.rename(func(key string) string { return strings.TrimPrefix(key, "app_") })
+
+			if !reflect.DeepEqual(test.expectedCollisions, actualCollisions) {
+				t.Errorf("Got %+v, wanted %+v", actualCollisions, test.expectedCollisions)
+			}
+
+			if actualResult := res.result(); !reflect.DeepEqual(test.expectedResult, actualResult) {
 				t.Errorf("Got %+v, wanted %+v", actualResult, test.expectedResult)
 			}
 		})
 	}
 }
+
+func TestInternalKnownResult(t *testing.T) {
+	var tests = []struct {
+		result   basetypes.MapValue
+		expected basetypes.MapValue
+	}{
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringUnknown(),
+				"c": basetypes.NewStringNull(),
+			}),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"c": basetypes.NewStringNull(),
+			}),
+		},
+		{
+			result: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringUnknown(),
+			}),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			result:   basetypes.NewMapUnknown(types.StringType),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			result:   basetypes.NewMapNull(types.StringType),
+			expected: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v", test.result)
+
+		t.Run(testname, func(t *testing.T) {
+			actual := knownResult(test.result)
+
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf("Got %+v, wanted %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestInternalPlanWarningOnEmptyResult(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	var tests = []struct {
+		planWarningOnEmptyResult bool
+		resultKeys               tftypes.Value
+		expectedWarnings         int
+	}{
+		{
+			planWarningOnEmptyResult: true,
+			resultKeys:               stringList(),
+			expectedWarnings:         1,
+		},
+		// result is not empty
+		{
+			planWarningOnEmptyResult: true,
+			resultKeys:               stringList("a"),
+			expectedWarnings:         0,
+		},
+		// not asked for
+		{
+			planWarningOnEmptyResult: false,
+			resultKeys:               stringList(),
+			expectedWarnings:         0,
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.planWarningOnEmptyResult, test.resultKeys)
+
+		t.Run(testname, func(t *testing.T) {
+			configured := map[string]tftypes.Value{
+				"keys":                         stringList("a", "b"),
+				"plan_warning_on_empty_result": tftypes.NewValue(tftypes.Bool, test.planWarningOnEmptyResult),
+				"result_keys":                  test.resultKeys,
+				"values":                       stringList("1", "2"),
+			}
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := configured[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+			var model mapModel
+			diagnostics := plan.Get(ctx, &model)
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			r.modify(ctx, model, basetypes.NewMapNull(types.StringType), &diagnostics, &plan, false)
+
+			if diagnostics.HasError() || diagnostics.WarningsCount() != test.expectedWarnings {
+				t.Errorf("Got %+v, wanted %d warnings", diagnostics, test.expectedWarnings)
+			}
+		})
+	}
+}
+
+func TestInternalAliasKeys(t *testing.T) {
+	var tests = []struct {
+		keys           []basetypes.StringValue
+		values         []basetypes.StringValue
+		resultKeys     []basetypes.StringValue
+		aliases        basetypes.MapValue
+		expectedKeys   []basetypes.StringValue
+		expectedValues []basetypes.StringValue
+		expectedError  bool
+	}{
+		// aliases are followed to the canonical key
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("production"), basetypes.NewStringValue("staging")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("live"), basetypes.NewStringValue("staging")},
+			aliases: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"live": basetypes.NewStringValue("prod"),
+				"prod": basetypes.NewStringValue("production"),
+			}),
+			expectedKeys:   []basetypes.StringValue{basetypes.NewStringValue("production"), basetypes.NewStringValue("live"), basetypes.NewStringValue("staging")},
+			expectedValues: []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringValue("1"), basetypes.NewStringValue("2")},
+		},
+		// cyclic aliases
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("production")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("prod")},
+			aliases: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"live": basetypes.NewStringValue("prod"),
+				"prod": basetypes.NewStringValue("live"),
+			}),
+			expectedKeys:   []basetypes.StringValue{basetypes.NewStringValue("production")},
+			expectedValues: []basetypes.StringValue{basetypes.NewStringValue("1")},
+			expectedError:  true,
+		},
+		// an unknown alias could be any result key
+		{
+			keys:       []basetypes.StringValue{basetypes.NewStringValue("production")},
+			values:     []basetypes.StringValue{basetypes.NewStringValue("1")},
+			resultKeys: []basetypes.StringValue{basetypes.NewStringValue("prod")},
+			aliases: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"prod": basetypes.NewStringUnknown(),
+			}),
+			expectedKeys:   []basetypes.StringValue{basetypes.NewStringValue("production"), basetypes.NewStringUnknown()},
+			expectedValues: []basetypes.StringValue{basetypes.NewStringValue("1"), basetypes.NewStringUnknown()},
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v,%+v", test.keys, test.resultKeys, test.aliases)
+
+		t.Run(testname, func(t *testing.T) {
+			var diagnostics diag.Diagnostics
+			actualKeys, actualValues := aliasKeys(test.keys, test.values, test.resultKeys, test.aliases, &diagnostics)
+
+			if diagnostics.HasError() != test.expectedError {
+				t.Errorf("Got %+v, wanted error %t", diagnostics, test.expectedError)
+			}
+
+			if !reflect.DeepEqual(test.expectedKeys, actualKeys) || !reflect.DeepEqual(test.expectedValues, actualValues) {
+				t.Errorf("Got %+v %+v, wanted %+v %+v", actualKeys, actualValues, test.expectedKeys, test.expectedValues)
+			}
+		})
+	}
+}
+
+func TestInternalValidateOnly(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{data: &resolverData{validateOnly: true}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	// These are not computed from the resolution, so they are the only computed attributes that are not withheld.
+	kept := map[string]bool{
+		"changed_keys":  true,
+		"errors":        true,
+		"id":            true,
+		"last_modified": true,
+		"result_tags":   true,
+		"version":       true,
+	}
+
+	for _, errorOnUnresolved := range []bool{false, true} {
+		testname := fmt.Sprintf("%t", errorOnUnresolved)
+
+		t.Run(testname, func(t *testing.T) {
+			configured := map[string]tftypes.Value{
+				"chunk_size":         tftypes.NewValue(tftypes.Number, 1),
+				"compute_complement": tftypes.NewValue(tftypes.Bool, true),
+				"keys":               stringList("a", "b", "c"),
+				"parse_values_as":    tftypes.NewValue(tftypes.String, "number"),
+				"result_groups":      tftypes.NewValue(tftypes.Map{ElementType: tftypes.List{ElementType: tftypes.String}}, map[string]tftypes.Value{"g": stringList("a")}),
+				"result_keys":        stringList("a", "c"),
+				"result_template":    tftypes.NewValue(tftypes.String, "{{.a}}"),
+				"values":             stringList("1", "2", "3"),
+			}
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := configured[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+			var model mapModel
+			diagnostics := plan.Get(ctx, &model)
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			r.modify(ctx, model, basetypes.NewMapNull(types.StringType), &diagnostics, &plan, errorOnUnresolved)
+			if diagnostics.HasError() {
+				t.Fatalf("Got errors %+v", diagnostics)
+			}
+
+			for name, attribute := range schemaResp.Schema.Attributes {
+				if !attribute.IsComputed() || attribute.IsOptional() || kept[name] {
+					continue
+				}
+
+				var value attr.Value
+				diagnostics.Append(plan.GetAttribute(ctx, path.Root(name), &value)...)
+
+				// Withheld values are unknown at plan and null at apply.
+				if diagnostics.HasError() || (errorOnUnresolved && !value.IsNull()) || (!errorOnUnresolved && !value.IsUnknown()) {
+					t.Errorf("Got %s %+v, wanted it withheld", name, value)
+				}
+			}
+		})
+	}
+}
+
+func TestInternalRefreshOutputs(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringMap := func(values map[string]string) tftypes.Value {
+		if values == nil {
+			return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil)
+		}
+		elements := make(map[string]tftypes.Value, len(values))
+		for key, value := range values {
+			elements[key] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+	}
+
+	var tests = []struct {
+		result             map[string]string
+		resultSafe         map[string]string
+		expectedResultSafe basetypes.MapValue
+	}{
+		{
+			result:     map[string]string{"a": "1", "b": "2"},
+			resultSafe: map[string]string{"a": "1", "b": "__UNKNOWN__"},
+			expectedResultSafe: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+		},
+		// withheld under validate_only
+		{
+			result:             nil,
+			resultSafe:         nil,
+			expectedResultSafe: basetypes.NewMapNull(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.resultSafe)
+
+		t.Run(testname, func(t *testing.T) {
+			stored := map[string]tftypes.Value{
+				"result":      stringMap(test.result),
+				"result_safe": stringMap(test.resultSafe),
+			}
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := stored[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+			req := fwresource.ReadRequest{State: state}
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Got %+v", resp.Diagnostics)
+			}
+
+			var model mapModel
+			resp.State.Get(ctx, &model)
+
+			if !reflect.DeepEqual(model.ResultSafe, test.expectedResultSafe) {
+				t.Errorf("Got %+v, wanted %+v", model.ResultSafe, test.expectedResultSafe)
+			}
+		})
+	}
+}
+
+func TestInternalRefreshKnownResult(t *testing.T) {
+	ctx := context.Background()
+	r := &MapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringMap := func(values map[string]string) tftypes.Value {
+		if values == nil {
+			return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil)
+		}
+		elements := make(map[string]tftypes.Value, len(values))
+		for key, value := range values {
+			elements[key] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+	}
+
+	var tests = []struct {
+		result              map[string]string
+		knownResult         map[string]string
+		expectedKnownResult basetypes.MapValue
+	}{
+		// the entries left out at plan are completed
+		{
+			result:      map[string]string{"a": "1", "b": "2"},
+			knownResult: map[string]string{"a": "1"},
+			expectedKnownResult: basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+				"a": basetypes.NewStringValue("1"),
+				"b": basetypes.NewStringValue("2"),
+			}),
+		},
+		// withheld under validate_only
+		{
+			result:              nil,
+			knownResult:         nil,
+			expectedKnownResult: basetypes.NewMapNull(types.StringType),
+		},
+	}
+
+	for _, test := range tests {
+		testname := fmt.Sprintf("%+v,%+v", test.result, test.knownResult)
+
+		t.Run(testname, func(t *testing.T) {
+			stored := map[string]tftypes.Value{
+				"known_result": stringMap(test.knownResult),
+				"result":       stringMap(test.result),
+			}
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := stored[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+			req := fwresource.ReadRequest{State: state}
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Got %+v", resp.Diagnostics)
+			}
+
+			var model mapModel
+			resp.State.Get(ctx, &model)
+
+			if !reflect.DeepEqual(model.KnownResult, test.expectedKnownResult) {
+				t.Errorf("Got %+v, wanted %+v", model.KnownResult, test.expectedKnownResult)
+			}
+		})
+	}
+}
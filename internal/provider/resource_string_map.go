@@ -0,0 +1,262 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ resource.ResourceWithModifyPlan = (*StringMapResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*StringMapResource)(nil)
+
+func NewStringMapResource() resource.Resource {
+	return &StringMapResource{}
+}
+
+// StringMapResource resolves like MapResource, with additional validation of the keys and values as strings.
+type StringMapResource struct {
+	MapResource
+}
+
+func (r *StringMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.applyMap(ctx, req.Plan, nil, nil, &resp.State, resp.Private, &resp.Diagnostics, r.read)
+}
+
+func (r *StringMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_string_map"
+}
+
+func (r *StringMapResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.planMap(ctx, req, resp, r.read)
+}
+
+func (r *StringMapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.MapResource.Schema(ctx, req, resp)
+
+	resp.Schema.MarkdownDescription = "Attempts to resolve a map when possible instead of the entire map being unknown at plan, like `resolver_map` but with validation of the keys and values as strings."
+
+	resp.Schema.Attributes["key_max_length"] = schema.Int64Attribute{
+		Description: "The maximum length of each known key.",
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(0),
+		},
+	}
+	resp.Schema.Attributes["key_min_length"] = schema.Int64Attribute{
+		Description: "The minimum length of each known key.",
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(0),
+		},
+	}
+	resp.Schema.Attributes["value_format"] = schema.StringAttribute{
+		Description: "The format each known, non-null value must have, one of \"any\" (the default), \"uuid\", \"arn\", \"url\" or \"email\".",
+		Optional:    true,
+		Validators: []validator.String{
+			stringvalidator.OneOf("any", "arn", "email", "url", "uuid"),
+		},
+	}
+	resp.Schema.Attributes["value_max_length"] = schema.Int64Attribute{
+		Description: "The maximum length of each known, non-null value.",
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(0),
+		},
+	}
+	resp.Schema.Attributes["value_min_length"] = schema.Int64Attribute{
+		Description: "The minimum length of each known, non-null value.",
+		Optional:    true,
+		Validators: []validator.Int64{
+			int64validator.AtLeast(0),
+		},
+	}
+}
+
+func (r *StringMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.applyMap(ctx, req.Plan, &req.State, req.Private, &resp.State, resp.Private, &resp.Diagnostics, r.read)
+}
+
+func (r *StringMapResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config stringMapModel
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_max_length"), &config.KeyMaxLength)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("key_min_length"), &config.KeyMinLength)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("keys"), &config.Keys)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_format"), &config.ValueFormat)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_max_length"), &config.ValueMaxLength)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_min_length"), &config.ValueMinLength)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("values"), &config.Values)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStrings(ctx, path.Root("keys"), config.Keys, lengthValidators(config.KeyMinLength, config.KeyMaxLength), &resp.Diagnostics)
+
+	valueValidators := lengthValidators(config.ValueMinLength, config.ValueMaxLength)
+	if format, ok := valueFormats[config.ValueFormat.ValueString()]; ok {
+		valueValidators = append(valueValidators, format)
+	}
+
+	validateStrings(ctx, path.Root("values"), config.Values, valueValidators, &resp.Diagnostics)
+}
+
+// stringMapModel holds the attributes that resolver_string_map has in addition to those of resolver_map.
+type stringMapModel struct {
+	KeyMaxLength   types.Int64  `tfsdk:"key_max_length"`
+	KeyMinLength   types.Int64  `tfsdk:"key_min_length"`
+	Keys           types.List   `tfsdk:"keys"`
+	ValueFormat    types.String `tfsdk:"value_format"`
+	ValueMaxLength types.Int64  `tfsdk:"value_max_length"`
+	ValueMinLength types.Int64  `tfsdk:"value_min_length"`
+	Values         types.List   `tfsdk:"values"`
+}
+
+// read is the mapReader of resolver_string_map. It gets the mapModel out of the plan, along with a PlanOrState for the
+// target that keeps the attributes only resolver_string_map has. The framework cannot read a struct that only has some of the attributes.
+func (r *StringMapResource) read(ctx context.Context, plan tfsdk.Plan, target PlanOrState, diagnostics *diag.Diagnostics) (mapModel, PlanOrState) {
+	var model mapModel
+	var object types.Object
+
+	diagnostics.Append(plan.Get(ctx, &object)...)
+	if diagnostics.HasError() {
+		return model, nil
+	}
+
+	mapType := r.mapType(ctx)
+	mapAttributes := make(map[string]attr.Value, len(mapType.AttrTypes))
+	extraTypes := make(map[string]attr.Type)
+	extraAttributes := make(map[string]attr.Value)
+
+	for name, value := range object.Attributes() {
+		if _, ok := mapType.AttrTypes[name]; ok {
+			mapAttributes[name] = value
+		} else {
+			extraTypes[name] = object.AttributeTypes(ctx)[name]
+			extraAttributes[name] = value
+		}
+	}
+
+	mapObject, diags := types.ObjectValue(mapType.AttrTypes, mapAttributes)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return model, nil
+	}
+
+	diagnostics.Append(mapObject.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+
+	return model, stringMapPlanOrState{
+		extraAttributes: extraAttributes,
+		extraTypes:      extraTypes,
+		mapType:         mapType,
+		target:          target,
+	}
+}
+
+// mapType returns the object type of the attributes shared with resolver_map.
+func (r *StringMapResource) mapType(ctx context.Context) types.ObjectType {
+	var resp resource.SchemaResponse
+	r.MapResource.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return resp.Schema.Type().(types.ObjectType)
+}
+
+// stringMapPlanOrState sets a mapModel on its target together with the attributes only resolver_string_map has.
+type stringMapPlanOrState struct {
+	extraAttributes map[string]attr.Value
+	extraTypes      map[string]attr.Type
+	mapType         types.ObjectType
+	target          PlanOrState
+}
+
+func (s stringMapPlanOrState) Set(ctx context.Context, val interface{}) diag.Diagnostics {
+	object, diags := types.ObjectValueFrom(ctx, s.mapType.AttrTypes, val)
+	if diags.HasError() {
+		return diags
+	}
+
+	attributeTypes := make(map[string]attr.Type, len(s.mapType.AttrTypes)+len(s.extraTypes))
+	attributes := make(map[string]attr.Value, len(attributeTypes))
+
+	for name, attributeType := range s.mapType.AttrTypes {
+		attributeTypes[name] = attributeType
+		attributes[name] = object.Attributes()[name]
+	}
+
+	for name, attributeType := range s.extraTypes {
+		attributeTypes[name] = attributeType
+		attributes[name] = s.extraAttributes[name]
+	}
+
+	full, fullDiags := types.ObjectValue(attributeTypes, attributes)
+	diags.Append(fullDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(s.target.Set(ctx, full)...)
+
+	return diags
+}
+
+var valueFormats = map[string]validator.String{
+	"arn":   stringvalidator.RegexMatches(regexp.MustCompile(`^arn:[^:]+:[^:]*:[^:]*:[^:]*:.+$`), "must be an ARN"),
+	"email": stringvalidator.RegexMatches(regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`), "must be an email address"),
+	"url":   stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://[^/?#\s]+\S*$`), "must be a URL"),
+	"uuid":  stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`), "must be a UUID"),
+}
+
+// lengthValidators returns validators for the minimum and maximum lengths that are set.
+func lengthValidators(minLength, maxLength types.Int64) []validator.String {
+	var validators []validator.String
+
+	if !minLength.IsNull() && !minLength.IsUnknown() {
+		validators = append(validators, stringvalidator.LengthAtLeast(int(minLength.ValueInt64())))
+	}
+
+	if !maxLength.IsNull() && !maxLength.IsUnknown() {
+		validators = append(validators, stringvalidator.LengthAtMost(int(maxLength.ValueInt64())))
+	}
+
+	return validators
+}
+
+// validateStrings runs the validators on each known, non-null element of list.
+func validateStrings(ctx context.Context, attribute path.Path, list types.List, validators []validator.String, diagnostics *diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return
+	}
+
+	for i, element := range list.Elements() {
+		value, ok := element.(basetypes.StringValue)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		for _, v := range validators {
+			req := validator.StringRequest{
+				ConfigValue:    value,
+				Path:           attribute.AtListIndex(i),
+				PathExpression: attribute.AtListIndex(i).Expression(),
+			}
+			resp := &validator.StringResponse{}
+
+			v.ValidateString(ctx, req, resp)
+			diagnostics.Append(resp.Diagnostics...)
+		}
+	}
+}
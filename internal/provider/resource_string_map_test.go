@@ -0,0 +1,166 @@
+// Copyright (c) Persona
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceStringMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_string_map" "test" {
+					key_max_length = 3
+					keys           = ["a", "b", "c"]
+					result_keys    = ["a", "c"]
+					value_format   = "uuid"
+					values         = [
+						"4f1d2b8e-5a3c-4e7b-9d6f-1a2b3c4d5e6f",
+						"0b6c1f2e-7d8a-4b9c-8e1f-2a3b4c5d6e7f",
+						"9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b",
+					]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resolver_string_map.test", "key_max_length", "3"),
+					resource.TestCheckResourceAttr("resolver_string_map.test", "result.%", "2"),
+					resource.TestCheckResourceAttr("resolver_string_map.test", "result.a", "4f1d2b8e-5a3c-4e7b-9d6f-1a2b3c4d5e6f"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceStringMapInvalidValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ErrorCheck: func(err error) error {
+			return err
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"resolver": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "resolver_string_map" "test" {
+					keys         = ["a", "b"]
+					result_keys  = ["a"]
+					value_format = "email"
+					values       = ["someone@example.com", "someone"]
+				}
+				`,
+
+				ExpectError: regexp.MustCompile(`(must be an email address)`),
+			},
+		},
+	})
+}
+
+func TestInternalStringMapReadAndSet(t *testing.T) {
+	ctx := context.Background()
+	r := &StringMapResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, value := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+	configured := map[string]tftypes.Value{
+		"key_min_length": tftypes.NewValue(tftypes.Number, 1),
+		"keys":           stringList("a", "b"),
+		"result_keys":    stringList("b"),
+		"values":         stringList("1", "2"),
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := configured[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	target := tfsdk.Plan{Schema: schemaResp.Schema}
+
+	var diagnostics diag.Diagnostics
+	model, state := r.read(ctx, plan, &target, &diagnostics)
+	if diagnostics.HasError() {
+		t.Fatalf("Got errors %+v", diagnostics)
+	}
+
+	r.modify(ctx, model, basetypes.NewMapNull(types.StringType), &diagnostics, state, false)
+
+	var keyMinLength types.Int64
+	var result types.Map
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("key_min_length"), &keyMinLength)...)
+	diagnostics.Append(target.GetAttribute(ctx, path.Root("result"), &result)...)
+
+	if diagnostics.HasError() {
+		t.Fatalf("Got errors %+v", diagnostics)
+	}
+
+	if keyMinLength.ValueInt64() != 1 {
+		t.Errorf("Got key_min_length %+v, wanted 1", keyMinLength)
+	}
+
+	expectedResult := basetypes.NewMapValueMust(types.StringType, map[string]attr.Value{
+		"b": basetypes.NewStringValue("2"),
+	})
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Errorf("Got %+v, wanted %+v", result, expectedResult)
+	}
+}
+
+func TestInternalValidateStrings(t *testing.T) {
+	list := basetypes.NewListValueMust(types.StringType, []attr.Value{
+		basetypes.NewStringValue("arn:aws:iam::123456789012:role/example"),
+		basetypes.NewStringValue("not-an-arn"),
+		basetypes.NewStringUnknown(),
+		basetypes.NewStringNull(),
+		basetypes.NewStringValue("arn:aws:s3:::a-very-very-long-bucket-name"),
+	})
+
+	validators := append(lengthValidators(types.Int64Value(1), types.Int64Value(38)), valueFormats["arn"])
+
+	var diagnostics diag.Diagnostics
+	validateStrings(context.Background(), path.Root("values"), list, validators, &diagnostics)
+
+	var paths []string
+	for _, diagnostic := range diagnostics.Errors() {
+		paths = append(paths, diagnostic.(diag.DiagnosticWithPath).Path().String())
+	}
+
+	expectedPaths := []string{"values[1]", "values[4]"}
+	if !reflect.DeepEqual(expectedPaths, paths) {
+		t.Errorf("Got errors at %+v, wanted %+v: %+v", paths, expectedPaths, diagnostics)
+	}
+}